@@ -0,0 +1,23 @@
+package api
+
+import "github.com/rakeyshgidwani/sunday-schemas/codegen/go/money"
+
+// Volume24hAmount returns volume_24h as exact USD. ok is false when the
+// market has no volume; sub-cent volumes are rejected with a
+// *money.PrecisionError.
+func (m Market) Volume24hAmount() (volume money.Money, ok bool, err error) {
+	if m.Volume24h == nil {
+		return money.Money{}, false, nil
+	}
+	amount, err := money.FromFloat32(*m.Volume24h)
+	if err != nil {
+		return money.Money{}, false, err
+	}
+	volume, err = money.USD(amount)
+	return volume, err == nil, err
+}
+
+// EdgeBpsDecimal returns edge_bps as an exact decimal
+func (a ArbLite) EdgeBpsDecimal() (money.Decimal, error) {
+	return money.FromFloat32(a.EdgeBps)
+}
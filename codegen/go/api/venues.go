@@ -0,0 +1,21 @@
+package api
+
+import "github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+
+// Valid reports whether the venue is in the venue registry
+func (v ArbLiteLongVenue) Valid() bool { return venues.IsValid(string(v)) }
+
+// Valid reports whether the venue is in the venue registry
+func (v ArbLiteShortVenue) Valid() bool { return venues.IsValid(string(v)) }
+
+// Valid reports whether the venue is in the venue registry
+func (v MarketVenues) Valid() bool { return venues.IsValid(string(v)) }
+
+// Valid reports whether the venue is in the venue registry
+func (v ResolutionEventVenueId) Valid() bool { return venues.IsValid(string(v)) }
+
+// Valid reports whether the venue is in the venue registry
+func (v VenueHealthVenueId) Valid() bool { return venues.IsValid(string(v)) }
+
+// Valid reports whether the venue is in the venue registry
+func (v WhaleLiteVenueId) Valid() bool { return venues.IsValid(string(v)) }
@@ -7,7 +7,12 @@
 // and will be updated when the underlying schemas change.
 package sundayschemas
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // Legacy type aliases for backward compatibility
 // These provide the interface that sunday-connectors expects
@@ -17,12 +22,19 @@ import "time"
 type Stream = RawEnvelopeV0Stream
 
 // Stream constants for backward compatibility
+//
+// Only StreamOrderbook, StreamTrades and StreamStatus are valid raw.v0 streams.
+// The discovery streams belong to the raw.events.v0/raw.series.v0/raw.categories.v0
+// envelopes; use EventDiscovery, SeriesDiscovery and CategoryDiscovery instead.
 const (
 	StreamOrderbook         Stream = Orderbook
 	StreamTrades           Stream = Trades
 	StreamStatus           Stream = Status
+	// Deprecated: not a raw.v0 stream; use EventDiscovery.
 	StreamEventDiscovery   Stream = "event_discovery"
+	// Deprecated: not a raw.v0 stream; use SeriesDiscovery.
 	StreamSeriesDiscovery  Stream = "series_discovery"
+	// Deprecated: not a raw.v0 stream; use CategoryDiscovery.
 	StreamCategoryDiscovery Stream = "category_discovery"
 )
 
@@ -57,8 +69,12 @@ func NewRawEnvelope(venueID, stream, instrument string, eventTS time.Time, paylo
 	}
 }
 
-// ToRawEnvelopeV0 converts a legacy RawEnvelope to the canonical RawEnvelopeV0
+// ToRawEnvelopeV0 converts a legacy RawEnvelope to the canonical RawEnvelopeV0.
+// A payload that is not a JSON object is dropped and nothing is validated.
+//
+// Deprecated: use ConvertToRawEnvelopeV0, which reports invalid envelopes.
 func (r RawEnvelope) ToRawEnvelopeV0() RawEnvelopeV0 {
+	payload, _ := PayloadMap(r.Payload)
 	return RawEnvelopeV0{
 		Schema:           RawV0, // Use the canonical schema constant
 		VenueID:          VenueID(r.VenueID),
@@ -69,7 +85,7 @@ func (r RawEnvelope) ToRawEnvelopeV0() RawEnvelopeV0 {
 		TsIngestMS:       r.TsIngestMs,  // Convert lowercase to uppercase
 		IsHistorical:     r.IsHistorical,
 		BackfillTsMS:     r.BackfillTsMs,
-		Payload:          r.Payload.(map[string]interface{}),
+		Payload:          payload,
 	}
 }
 
@@ -96,8 +112,8 @@ type OrderbookDelta struct {
 	VenueID      string      `json:"venue_id"`
 	TsMs         int64       `json:"ts_ms"`
 	Seq          int64       `json:"seq"`
-	Bids         [][]float64 `json:"bids"`
-	Asks         [][]float64 `json:"asks"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
 	IsSnapshot   bool        `json:"is_snapshot"`
 }
 
@@ -114,6 +130,9 @@ type Trade struct {
 }
 
 // ToNormalizedTradeV1 converts a legacy Trade to canonical NormalizedTradeV1
+// without validating it.
+//
+// Deprecated: use ConvertToNormalizedTradeV1, which reports invalid trades.
 func (t Trade) ToNormalizedTradeV1() NormalizedTradeV1 {
 	return NormalizedTradeV1{
 		Schema:       MdTradeV1,
@@ -139,4 +158,118 @@ func FromNormalizedTradeV1(trade NormalizedTradeV1) Trade {
 		Size:         trade.Size,
 		NotionalUsd:  trade.NotionalUsd,
 	}
-}
\ No newline at end of file
+}
+
+// ConvertToRawEnvelopeV0 converts a legacy RawEnvelope to the canonical
+// RawEnvelopeV0, validating the schema, venue and stream. The payload may be
+// any value that marshals to a JSON object (see PayloadMap).
+func (r RawEnvelope) ConvertToRawEnvelopeV0() (RawEnvelopeV0, error) {
+	if r.Schema != "" && r.Schema != string(RawV0) {
+		return RawEnvelopeV0{}, fmt.Errorf("raw envelope: invalid schema: %s", r.Schema)
+	}
+	if err := ValidateVenueStream(VenueID(r.VenueID), RawEnvelopeV0Stream(r.Stream)); err != nil {
+		return RawEnvelopeV0{}, fmt.Errorf("raw envelope: %w", err)
+	}
+	payload, err := PayloadMap(r.Payload)
+	if err != nil {
+		return RawEnvelopeV0{}, fmt.Errorf("raw envelope: %w", err)
+	}
+
+	env := r.ToRawEnvelopeV0()
+	env.Payload = payload
+	return env, nil
+}
+
+// PayloadMap converts a raw.v0 payload to the generic map form used by
+// RawEnvelopeV0. It accepts maps, json.RawMessage, []byte holding a JSON
+// object, and any struct or other value that marshals to a JSON object.
+func PayloadMap(payload any) (map[string]interface{}, error) {
+	var data []byte
+	switch p := payload.(type) {
+	case nil:
+		return nil, fmt.Errorf("payload is required")
+	case map[string]interface{}:
+		if p == nil {
+			return nil, fmt.Errorf("payload is required")
+		}
+		return p, nil
+	case json.RawMessage:
+		data = p
+	case []byte:
+		data = p
+	default:
+		var err error
+		if data, err = json.Marshal(p); err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, fmt.Errorf("payload must be a JSON object")
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+	return out, nil
+}
+
+// ConvertToNormalizedTradeV1 converts a legacy Trade to canonical
+// NormalizedTradeV1, validating the schema, venue and side
+func (t Trade) ConvertToNormalizedTradeV1() (NormalizedTradeV1, error) {
+	if t.Schema != "" && t.Schema != string(MdTradeV1) {
+		return NormalizedTradeV1{}, fmt.Errorf("trade: invalid schema: %s", t.Schema)
+	}
+	if err := ValidateVenue(t.VenueID); err != nil {
+		return NormalizedTradeV1{}, fmt.Errorf("trade: %w", err)
+	}
+	if err := ValidateDirection(t.Side); err != nil {
+		return NormalizedTradeV1{}, fmt.Errorf("trade: %w", err)
+	}
+	return t.ToNormalizedTradeV1(), nil
+}
+
+// ToNormalizedOrderBookDeltaV1 converts a legacy OrderbookDelta to canonical
+// NormalizedOrderBookDeltaV1 without validating it
+func (d OrderbookDelta) ToNormalizedOrderBookDeltaV1() NormalizedOrderBookDeltaV1 {
+	return NormalizedOrderBookDeltaV1{
+		Schema:       MdOrderbookDeltaV1,
+		InstrumentID: d.InstrumentID,
+		VenueID:      VenueID(d.VenueID),
+		TsMS:         d.TsMs,
+		Seq:          d.Seq,
+		Bids:         d.Bids,
+		Asks:         d.Asks,
+		IsSnapshot:   d.IsSnapshot,
+	}
+}
+
+// ConvertToNormalizedOrderBookDeltaV1 converts a legacy OrderbookDelta to
+// canonical NormalizedOrderBookDeltaV1, validating the schema, venue and
+// price ladders
+func (d OrderbookDelta) ConvertToNormalizedOrderBookDeltaV1() (NormalizedOrderBookDeltaV1, error) {
+	if d.Schema != "" && d.Schema != string(MdOrderbookDeltaV1) {
+		return NormalizedOrderBookDeltaV1{}, fmt.Errorf("orderbook delta: invalid schema: %s", d.Schema)
+	}
+	if err := ValidateVenue(d.VenueID); err != nil {
+		return NormalizedOrderBookDeltaV1{}, fmt.Errorf("orderbook delta: %w", err)
+	}
+	if err := ValidateBids(d.Bids); err != nil {
+		return NormalizedOrderBookDeltaV1{}, fmt.Errorf("orderbook delta: bids: %w", err)
+	}
+	if err := ValidateAsks(d.Asks); err != nil {
+		return NormalizedOrderBookDeltaV1{}, fmt.Errorf("orderbook delta: asks: %w", err)
+	}
+	return d.ToNormalizedOrderBookDeltaV1(), nil
+}
+
+// ValidateDirection checks if a trade side is a valid Direction
+func ValidateDirection(side string) error {
+	switch Direction(side) {
+	case Buy, Sell:
+		return nil
+	default:
+		return fmt.Errorf("invalid side: %s", side)
+	}
+}
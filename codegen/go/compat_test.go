@@ -0,0 +1,100 @@
+package sundayschemas
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRawEnvelope_ConvertToRawEnvelopeV0_Payloads(t *testing.T) {
+	type bookPayload struct {
+		Market string `json:"market"`
+		Size   int    `json:"size"`
+	}
+
+	tests := []struct {
+		name    string
+		payload any
+		wantErr bool
+	}{
+		{"map", map[string]interface{}{"market": "0x1", "size": 5.0}, false},
+		{"struct", bookPayload{Market: "0x1", Size: 5}, false},
+		{"struct pointer", &bookPayload{Market: "0x1", Size: 5}, false},
+		{"raw message", json.RawMessage(`{"market":"0x1","size":5}`), false},
+		{"bytes", []byte(` {"market":"0x1","size":5}`), false},
+		{"nil", nil, true},
+		{"nil map", map[string]interface{}(nil), true},
+		{"array", []int{1, 2}, true},
+		{"raw array", json.RawMessage(`[1,2]`), true},
+		{"malformed bytes", []byte(`{"market":`), true},
+		{"unmarshalable", struct{ F func() }{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := NewRawEnvelope("polymarket", "orderbook", "0x1", time.UnixMilli(1000), tt.payload)
+			env, err := legacy.ConvertToRawEnvelopeV0()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertToRawEnvelopeV0() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && env.Payload["market"] != "0x1" {
+				t.Errorf("Payload = %v", env.Payload)
+			}
+		})
+	}
+}
+
+func TestRawEnvelope_ConvertToRawEnvelopeV0_Validation(t *testing.T) {
+	payload := map[string]interface{}{"k": "v"}
+	tests := []struct {
+		name string
+		env  RawEnvelope
+	}{
+		{"unknown venue", NewRawEnvelope("manifold", "trades", "x", time.Now(), payload)},
+		{"unknown stream", NewRawEnvelope("kalshi", "quotes", "x", time.Now(), payload)},
+		{"discovery stream", NewRawEnvelope("kalshi", string(StreamEventDiscovery), "x", time.Now(), payload)},
+		{"wrong schema", RawEnvelope{Schema: "md.trade.v1", VenueID: "kalshi", Stream: "trades", Payload: payload}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.env.ConvertToRawEnvelopeV0(); err == nil {
+				t.Error("ConvertToRawEnvelopeV0() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestRawEnvelope_ToRawEnvelopeV0_DoesNotPanic(t *testing.T) {
+	for _, payload := range []any{nil, json.RawMessage(`{"a":1}`), struct{ A int }{1}, "text"} {
+		env := NewRawEnvelope("kalshi", "trades", "x", time.Now(), payload).ToRawEnvelopeV0()
+		if env.Schema != RawV0 {
+			t.Errorf("ToRawEnvelopeV0() schema = %v", env.Schema)
+		}
+	}
+}
+
+func TestTrade_ConvertToNormalizedTradeV1(t *testing.T) {
+	valid := Trade{Schema: "md.trade.v1", InstrumentID: "i", VenueID: "kalshi", TsMs: 1, Side: "sell", Prob: 0.4, Size: 2}
+	trade, err := valid.ConvertToNormalizedTradeV1()
+	if err != nil {
+		t.Fatalf("ConvertToNormalizedTradeV1() error = %v", err)
+	}
+	if trade.Side != Sell || trade.VenueID != VenueKalshi || trade.Schema != MdTradeV1 {
+		t.Errorf("ConvertToNormalizedTradeV1() = %+v", trade)
+	}
+
+	for name, mutate := range map[string]func(*Trade){
+		"bad side":   func(t *Trade) { t.Side = "SELL" },
+		"bad venue":  func(t *Trade) { t.VenueID = "manifold" },
+		"bad schema": func(t *Trade) { t.Schema = "md.trade.v2" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := valid
+			mutate(&invalid)
+			if _, err := invalid.ConvertToNormalizedTradeV1(); err == nil {
+				t.Error("ConvertToNormalizedTradeV1() expected error, got nil")
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ package sundayschemas
 
 import (
 	"fmt"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
 )
 
 // EventSchema represents all valid schema identifiers
@@ -20,6 +22,9 @@ const (
 	SchemaINSIGHTS_WHALES_LITE_V1 EventSchema = "insights.whales.lite.v1"
 	SchemaMD_ORDERBOOK_DELTA_V1 EventSchema = "md.orderbook.delta.v1"
 	SchemaMD_TRADE_V1 EventSchema = "md.trade.v1"
+	SchemaRAW_CATEGORIES_V0 EventSchema = "raw.categories.v0"
+	SchemaRAW_EVENTS_V0 EventSchema = "raw.events.v0"
+	SchemaRAW_SERIES_V0 EventSchema = "raw.series.v0"
 	SchemaRAW_V0 EventSchema = "raw.v0"
 )
 
@@ -49,21 +54,16 @@ const (
 // ValidateSchema checks if a schema string is valid
 func ValidateSchema(schema string) error {
 	switch EventSchema(schema) {
-	case SchemaINFRA_VENUE_HEALTH_V1, SchemaINSIGHTS_ARB_LITE_V1, SchemaINSIGHTS_MOVERS_V1, SchemaINSIGHTS_UNUSUAL_V1, SchemaINSIGHTS_WHALES_LITE_V1, SchemaMD_ORDERBOOK_DELTA_V1, SchemaMD_TRADE_V1, SchemaRAW_V0:
+	case SchemaINFRA_VENUE_HEALTH_V1, SchemaINSIGHTS_ARB_LITE_V1, SchemaINSIGHTS_MOVERS_V1, SchemaINSIGHTS_UNUSUAL_V1, SchemaINSIGHTS_WHALES_LITE_V1, SchemaMD_ORDERBOOK_DELTA_V1, SchemaMD_TRADE_V1, SchemaRAW_CATEGORIES_V0, SchemaRAW_EVENTS_V0, SchemaRAW_SERIES_V0, SchemaRAW_V0:
 		return nil
 	default:
 		return fmt.Errorf("invalid schema: %s", schema)
 	}
 }
 
-// ValidateVenue checks if a venue ID is valid
+// ValidateVenue checks if a venue ID is in the venue registry
 func ValidateVenue(venue string) error {
-	switch VenueID(venue) {
-	case VenuePolymarket, VenueKalshi:
-		return nil
-	default:
-		return fmt.Errorf("invalid venue: %s", venue)
-	}
+	return venues.Validate(venue)
 }
 
 // AllSchemas returns all valid schema constants
@@ -76,11 +76,19 @@ func AllSchemas() []EventSchema {
 		SchemaINSIGHTS_WHALES_LITE_V1,
 		SchemaMD_ORDERBOOK_DELTA_V1,
 		SchemaMD_TRADE_V1,
+		SchemaRAW_CATEGORIES_V0,
+		SchemaRAW_EVENTS_V0,
+		SchemaRAW_SERIES_V0,
 		SchemaRAW_V0,
 	}
 }
 
-// AllVenues returns all valid venue IDs
+// AllVenues returns all venue IDs in the venue registry
 func AllVenues() []VenueID {
-	return []VenueID{VenuePolymarket, VenueKalshi}
+	ids := venues.IDs()
+	out := make([]VenueID, len(ids))
+	for i, id := range ids {
+		out[i] = VenueID(id)
+	}
+	return out
 }
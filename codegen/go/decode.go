@@ -0,0 +1,106 @@
+// Package sundayschemas provides schema-dispatching decoding for Sunday messages
+package sundayschemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrMissingSchema is returned when a message carries no schema identifier
+var ErrMissingSchema = errors.New("message has no schema identifier")
+
+// decoderFunc decodes raw bytes into the typed value for one schema
+type decoderFunc func(data []byte) (any, error)
+
+// decoders maps every known schema identifier to its typed unmarshaler
+var decoders = map[EventSchema]decoderFunc{
+	SchemaINFRA_VENUE_HEALTH_V1: func(data []byte) (any, error) {
+		return UnmarshalVenueHealthV1(data)
+	},
+	SchemaINSIGHTS_ARB_LITE_V1: func(data []byte) (any, error) {
+		return UnmarshalArbitrageLiteV1(data)
+	},
+	SchemaINSIGHTS_MOVERS_V1: func(data []byte) (any, error) {
+		return UnmarshalMoversV1(data)
+	},
+	SchemaINSIGHTS_UNUSUAL_V1: func(data []byte) (any, error) {
+		return UnmarshalUnusualActivityV1(data)
+	},
+	SchemaINSIGHTS_WHALES_LITE_V1: func(data []byte) (any, error) {
+		return UnmarshalWhaleFlowsLiteV1(data)
+	},
+	SchemaMD_ORDERBOOK_DELTA_V1: func(data []byte) (any, error) {
+		return UnmarshalNormalizedOrderBookDeltaV1(data)
+	},
+	SchemaMD_TRADE_V1: func(data []byte) (any, error) {
+		return UnmarshalNormalizedTradeV1(data)
+	},
+	SchemaRAW_CATEGORIES_V0: func(data []byte) (any, error) {
+		return UnmarshalRawCategoriesDiscoveryV0(data)
+	},
+	SchemaRAW_EVENTS_V0: func(data []byte) (any, error) {
+		return UnmarshalRawEventsDiscoveryV0(data)
+	},
+	SchemaRAW_SERIES_V0: func(data []byte) (any, error) {
+		return UnmarshalRawSeriesDiscoveryV0(data)
+	},
+	SchemaRAW_V0: func(data []byte) (any, error) {
+		return UnmarshalRawEnvelopeV0(data)
+	},
+}
+
+// PeekSchema reads the schema identifier of a message without decoding it.
+// The identifier is taken from the top-level "schema" field, or from
+// "envelope.schema" for the raw discovery schemas.
+func PeekSchema(data []byte) (EventSchema, error) {
+	var probe struct {
+		Schema   string `json:"schema"`
+		Envelope *struct {
+			Schema string `json:"schema"`
+		} `json:"envelope"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", fmt.Errorf("failed to read schema identifier: %w", err)
+	}
+
+	schema := probe.Schema
+	if schema == "" && probe.Envelope != nil {
+		schema = probe.Envelope.Schema
+	}
+	if schema == "" {
+		return "", ErrMissingSchema
+	}
+
+	return EventSchema(schema), nil
+}
+
+// Decode reads the schema identifier of a message and unmarshals it into the
+// matching typed value, e.g. NormalizedTradeV1 for "md.trade.v1" or
+// RawEventsDiscoveryV0 for "raw.events.v0". Messages at an older version of
+// a schema are upcast by DefaultUpcasters, and the returned identifier is the
+// version of the returned value. Unknown identifiers are reported as
+// UnknownSchemaError.
+func Decode(data []byte) (any, EventSchema, error) {
+	return DefaultUpcasters().Decode(data)
+}
+
+// decode unmarshals a message at the version it declares
+func decode(data []byte) (any, EventSchema, error) {
+	schema, err := PeekSchema(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fn, ok := decoders[schema]
+	if !ok {
+		return nil, schema, UnknownSchemaError{Schema: string(schema)}
+	}
+
+	v, err := fn(data)
+	if err != nil {
+		return nil, schema, fmt.Errorf("failed to decode %s: %w", schema, err)
+	}
+
+	return v, schema, nil
+}
@@ -0,0 +1,148 @@
+package sundayschemas
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecode_Examples(t *testing.T) {
+	tests := []struct {
+		filename string
+		schema   EventSchema
+		check    func(t *testing.T, v any)
+	}{
+		{"md.trade.buy.example.json", SchemaMD_TRADE_V1, func(t *testing.T, v any) {
+			trade, ok := v.(NormalizedTradeV1)
+			if !ok {
+				t.Fatalf("got %T, want NormalizedTradeV1", v)
+			}
+			if trade.Side != Buy {
+				t.Errorf("Side = %v, want buy", trade.Side)
+			}
+		}},
+		{"md.orderbook.snapshot.example.json", SchemaMD_ORDERBOOK_DELTA_V1, func(t *testing.T, v any) {
+			if _, ok := v.(NormalizedOrderBookDeltaV1); !ok {
+				t.Fatalf("got %T, want NormalizedOrderBookDeltaV1", v)
+			}
+		}},
+		{"insights.arb.lite.example.json", SchemaINSIGHTS_ARB_LITE_V1, func(t *testing.T, v any) {
+			if _, ok := v.(ArbitrageLiteV1); !ok {
+				t.Fatalf("got %T, want ArbitrageLiteV1", v)
+			}
+		}},
+		{"insights.movers.example.json", SchemaINSIGHTS_MOVERS_V1, func(t *testing.T, v any) {
+			if _, ok := v.(MoversV1); !ok {
+				t.Fatalf("got %T, want MoversV1", v)
+			}
+		}},
+		{"insights.unusual.example.json", SchemaINSIGHTS_UNUSUAL_V1, func(t *testing.T, v any) {
+			if _, ok := v.(UnusualActivityV1); !ok {
+				t.Fatalf("got %T, want UnusualActivityV1", v)
+			}
+		}},
+		{"insights.whales.lite.example.json", SchemaINSIGHTS_WHALES_LITE_V1, func(t *testing.T, v any) {
+			if _, ok := v.(WhaleFlowsLiteV1); !ok {
+				t.Fatalf("got %T, want WhaleFlowsLiteV1", v)
+			}
+		}},
+		{"infra.venue_health.degraded.example.json", SchemaINFRA_VENUE_HEALTH_V1, func(t *testing.T, v any) {
+			health, ok := v.(VenueHealthV1)
+			if !ok {
+				t.Fatalf("got %T, want VenueHealthV1", v)
+			}
+			if health.Status != Degraded {
+				t.Errorf("Status = %v, want DEGRADED", health.Status)
+			}
+		}},
+		{"raw.kalshi.trade.example.json", SchemaRAW_V0, func(t *testing.T, v any) {
+			env, ok := v.(RawEnvelopeV0)
+			if !ok {
+				t.Fatalf("got %T, want RawEnvelopeV0", v)
+			}
+			if env.VenueID != Kalshi {
+				t.Errorf("VenueID = %v, want kalshi", env.VenueID)
+			}
+		}},
+		{"raw.events.polymarket.example.json", SchemaRAW_EVENTS_V0, func(t *testing.T, v any) {
+			raw, ok := v.(RawEventsDiscoveryV0)
+			if !ok {
+				t.Fatalf("got %T, want RawEventsDiscoveryV0", v)
+			}
+			if raw.Envelope.Stream != EventDiscovery {
+				t.Errorf("Stream = %v, want event_discovery", raw.Envelope.Stream)
+			}
+		}},
+		{"raw.series.kalshi.example.json", SchemaRAW_SERIES_V0, func(t *testing.T, v any) {
+			if _, ok := v.(RawSeriesDiscoveryV0); !ok {
+				t.Fatalf("got %T, want RawSeriesDiscoveryV0", v)
+			}
+		}},
+		{"raw.categories.polymarket.example.json", SchemaRAW_CATEGORIES_V0, func(t *testing.T, v any) {
+			raw, ok := v.(RawCategoriesDiscoveryV0)
+			if !ok {
+				t.Fatalf("got %T, want RawCategoriesDiscoveryV0", v)
+			}
+			if raw.Payload["name"] != "Politics" {
+				t.Errorf("payload name = %v, want Politics", raw.Payload["name"])
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			data := loadExample(t, filepath.Join("..", "..", "schemas", "examples", tt.filename))
+			v, schema, err := Decode(data)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if schema != tt.schema {
+				t.Errorf("Decode() schema = %v, want %v", schema, tt.schema)
+			}
+			tt.check(t, v)
+		})
+	}
+}
+
+func TestDecode_CoversAllSchemas(t *testing.T) {
+	for _, schema := range AllSchemas() {
+		if _, ok := decoders[schema]; !ok {
+			t.Errorf("no decoder registered for %s", schema)
+		}
+	}
+}
+
+func TestDecode_UnknownSchema(t *testing.T) {
+	_, schema, err := Decode([]byte(`{"schema":"md.trade.v7"}`))
+	var unknown UnknownSchemaError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Decode() error = %v, want UnknownSchemaError", err)
+	}
+	if schema != "md.trade.v7" || unknown.Schema != "md.trade.v7" {
+		t.Errorf("Decode() schema = %q, error schema = %q, want md.trade.v7", schema, unknown.Schema)
+	}
+}
+
+func TestDecode_MissingSchema(t *testing.T) {
+	for _, doc := range []string{`{}`, `{"envelope":{}}`, `{"schema":""}`} {
+		if _, _, err := Decode([]byte(doc)); !errors.Is(err, ErrMissingSchema) {
+			t.Errorf("Decode(%s) error = %v, want ErrMissingSchema", doc, err)
+		}
+	}
+}
+
+func TestDecode_MalformedPayload(t *testing.T) {
+	_, schema, err := Decode([]byte(`{"schema":"md.trade.v1","prob":"high"}`))
+	if err == nil {
+		t.Fatal("Decode() expected error for mistyped field, got nil")
+	}
+	if schema != SchemaMD_TRADE_V1 {
+		t.Errorf("Decode() schema = %v, want md.trade.v1", schema)
+	}
+}
+
+func TestPeekSchema_InvalidJSON(t *testing.T) {
+	if _, err := PeekSchema([]byte(`not json`)); err == nil {
+		t.Error("PeekSchema() expected error for invalid JSON, got nil")
+	}
+}
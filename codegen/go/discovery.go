@@ -0,0 +1,567 @@
+// Package sundayschemas converts between the generated discovery types and the discovery package
+package sundayschemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/discovery"
+)
+
+// The root package has quicktype-generated discovery types (EventMetadataV0,
+// SeriesMetadataV0, EventDiscoveryPayloadV0, ...) and the discovery package
+// has hand-written ones for the same schemas. The conversions below map field
+// by field, without a JSON round trip. Slices, maps and pointers are shared
+// with the source, not copied.
+//
+// EventClass has the same fields as EventMetadataV0, and
+// SeriesDiscoveryPayloadV0Event the same as SeriesMetadataV0, so they convert
+// with a plain type conversion, e.g. EventMetadataV0(class).
+
+// ConversionError reports a value that the target type cannot represent, such
+// as a missing active flag or a count that overflows int. Field is the JSON
+// Pointer of the value.
+type ConversionError struct {
+	Field   string
+	Message string
+}
+
+func (e ConversionError) Error() string {
+	return fmt.Sprintf("discovery conversion: %s: %s", e.Field, e.Message)
+}
+
+// ToDiscovery converts generated event metadata to discovery.EventMetadataV0
+func (m EventMetadataV0) ToDiscovery() discovery.EventMetadataV0 {
+	return discovery.EventMetadataV0{
+		Kind:              discovery.DiscoveryKind(m.Kind),
+		VenueID:           discovery.VenueID(m.VenueID),
+		EventID:           m.EventID,
+		Title:             m.Title,
+		Description:       m.Description,
+		Category:          m.Category,
+		Active:            &m.Active,
+		Closed:            &m.Closed,
+		StartDate:         m.StartDate,
+		EndDate:           m.EndDate,
+		ParentSeriesID:    m.ParentSeriesID,
+		ParentSeriesTitle: m.ParentSeriesTitle,
+		Tags:              m.Tags,
+		Relationships:     m.Relationships.ToDiscovery(),
+		DiscoveredAt:      m.DiscoveredAt,
+		LastSeen:          m.LastSeen,
+		ExtraMetadata:     m.ExtraMetadata,
+	}
+}
+
+// FromDiscoveryEventMetadata converts discovery.EventMetadataV0 to the
+// generated type. Active and Closed are required.
+func FromDiscoveryEventMetadata(m discovery.EventMetadataV0) (EventMetadataV0, error) {
+	return fromDiscoveryEventMetadata("", m)
+}
+
+func fromDiscoveryEventMetadata(path string, m discovery.EventMetadataV0) (EventMetadataV0, error) {
+	active, err := requiredBool(path+"/active", m.Active)
+	if err != nil {
+		return EventMetadataV0{}, err
+	}
+	closed, err := requiredBool(path+"/closed", m.Closed)
+	if err != nil {
+		return EventMetadataV0{}, err
+	}
+	return EventMetadataV0{
+		Active:            active,
+		Category:          m.Category,
+		Closed:            closed,
+		Description:       m.Description,
+		DiscoveredAt:      m.DiscoveredAt,
+		EndDate:           m.EndDate,
+		EventID:           m.EventID,
+		ExtraMetadata:     m.ExtraMetadata,
+		Kind:              EventMetadataV0Kind(m.Kind),
+		LastSeen:          m.LastSeen,
+		ParentSeriesID:    m.ParentSeriesID,
+		ParentSeriesTitle: m.ParentSeriesTitle,
+		Relationships:     FromDiscoveryRelationships(m.Relationships),
+		StartDate:         m.StartDate,
+		Tags:              m.Tags,
+		Title:             m.Title,
+		VenueID:           VenueID(m.VenueID),
+	}, nil
+}
+
+// ToDiscovery converts generated series metadata to
+// discovery.SeriesMetadataV0. It fails only if a contract count overflows int.
+func (m SeriesMetadataV0) ToDiscovery() (discovery.SeriesMetadataV0, error) {
+	return m.toDiscovery("")
+}
+
+func (m SeriesMetadataV0) toDiscovery(path string) (discovery.SeriesMetadataV0, error) {
+	seriesData, err := m.SeriesData.toDiscovery(path + "/series_data")
+	if err != nil {
+		return discovery.SeriesMetadataV0{}, err
+	}
+	return discovery.SeriesMetadataV0{
+		Kind:          discovery.DiscoveryKind(m.Kind),
+		VenueID:       discovery.VenueID(m.VenueID),
+		EventID:       m.EventID,
+		Title:         m.Title,
+		Description:   m.Description,
+		Category:      m.Category,
+		Active:        &m.Active,
+		Closed:        &m.Closed,
+		Tags:          m.Tags,
+		ChildEventIDs: m.ChildEventIDS,
+		Relationships: m.Relationships.ToDiscovery(),
+		DiscoveredAt:  m.DiscoveredAt,
+		LastSeen:      m.LastSeen,
+		SeriesData:    seriesData,
+		ExtraMetadata: m.ExtraMetadata,
+	}, nil
+}
+
+// FromDiscoverySeriesMetadata converts discovery.SeriesMetadataV0 to the
+// generated type. Active and Closed are required.
+func FromDiscoverySeriesMetadata(m discovery.SeriesMetadataV0) (SeriesMetadataV0, error) {
+	return fromDiscoverySeriesMetadata("", m)
+}
+
+func fromDiscoverySeriesMetadata(path string, m discovery.SeriesMetadataV0) (SeriesMetadataV0, error) {
+	active, err := requiredBool(path+"/active", m.Active)
+	if err != nil {
+		return SeriesMetadataV0{}, err
+	}
+	closed, err := requiredBool(path+"/closed", m.Closed)
+	if err != nil {
+		return SeriesMetadataV0{}, err
+	}
+	return SeriesMetadataV0{
+		Active:        active,
+		Category:      m.Category,
+		ChildEventIDS: m.ChildEventIDs,
+		Closed:        closed,
+		Description:   m.Description,
+		DiscoveredAt:  m.DiscoveredAt,
+		EventID:       m.EventID,
+		ExtraMetadata: m.ExtraMetadata,
+		Kind:          SeriesMetadataV0Kind(m.Kind),
+		LastSeen:      m.LastSeen,
+		Relationships: FromDiscoveryRelationships(m.Relationships),
+		SeriesData:    FromDiscoverySeriesData(m.SeriesData),
+		Tags:          m.Tags,
+		Title:         m.Title,
+		VenueID:       VenueID(m.VenueID),
+	}, nil
+}
+
+// ToDiscovery converts generated relationships to discovery.RelationshipsV0;
+// nil stays nil
+func (r *Relationships) ToDiscovery() *discovery.RelationshipsV0 {
+	if r == nil {
+		return nil
+	}
+	return &discovery.RelationshipsV0{SeriesID: r.SeriesID, EventIDs: r.EventIDS, InstrumentIDs: r.InstrumentIDS}
+}
+
+// FromDiscoveryRelationships converts discovery.RelationshipsV0 to the
+// generated type; nil stays nil
+func FromDiscoveryRelationships(r *discovery.RelationshipsV0) *Relationships {
+	if r == nil {
+		return nil
+	}
+	return &Relationships{EventIDS: r.EventIDs, InstrumentIDS: r.InstrumentIDs, SeriesID: r.SeriesID}
+}
+
+// ToDiscovery converts generated series data to discovery.SeriesDataV0; nil
+// stays nil. It fails only if a contract count overflows int.
+func (d *SeriesData) ToDiscovery() (*discovery.SeriesDataV0, error) {
+	return d.toDiscovery("")
+}
+
+func (d *SeriesData) toDiscovery(path string) (*discovery.SeriesDataV0, error) {
+	if d == nil {
+		return nil, nil
+	}
+	financial, err := d.Financial.toDiscovery(path + "/financial")
+	if err != nil {
+		return nil, err
+	}
+	out := &discovery.SeriesDataV0{
+		Ticker:     d.Ticker,
+		Slug:       d.Slug,
+		Subtitle:   d.Subtitle,
+		SeriesType: d.SeriesType,
+		Recurrence: d.Recurrence,
+		ImageURL:   d.ImageURL,
+		IconURL:    d.IconURL,
+		Layout:     d.Layout,
+		Financial:  financial,
+		Status:     d.Status.ToDiscovery(),
+	}
+	if c := d.Contract; c != nil {
+		out.Contract = &discovery.ContractDataV0{
+			ContractURL:            c.ContractURL,
+			ContractTermsURL:       c.ContractTermsURL,
+			FeeType:                c.FeeType,
+			FeeMultiplier:          c.FeeMultiplier,
+			AdditionalProhibitions: c.AdditionalProhibitions,
+		}
+		if c.SettlementSources != nil {
+			out.Contract.SettlementSources = make([]discovery.SettlementSourceV0, len(c.SettlementSources))
+			for i, s := range c.SettlementSources {
+				out.Contract.SettlementSources[i] = discovery.SettlementSourceV0{Name: s.Name, URL: s.URL}
+			}
+		}
+	}
+	if t := d.Timestamps; t != nil {
+		out.Timestamps = &discovery.TimestampDataV0{PublishedAt: t.PublishedAt, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt}
+	}
+	if c := d.Creators; c != nil {
+		out.Creators = &discovery.CreatorDataV0{CreatedBy: c.CreatedBy, UpdatedBy: c.UpdatedBy}
+	}
+	return out, nil
+}
+
+// FromDiscoverySeriesData converts discovery.SeriesDataV0 to the generated
+// type; nil stays nil
+func FromDiscoverySeriesData(d *discovery.SeriesDataV0) *SeriesData {
+	if d == nil {
+		return nil
+	}
+	out := &SeriesData{
+		Financial:  FromDiscoveryFinancial(d.Financial),
+		IconURL:    d.IconURL,
+		ImageURL:   d.ImageURL,
+		Layout:     d.Layout,
+		Recurrence: d.Recurrence,
+		SeriesType: d.SeriesType,
+		Slug:       d.Slug,
+		Status:     FromDiscoveryStatus(d.Status),
+		Subtitle:   d.Subtitle,
+		Ticker:     d.Ticker,
+	}
+	if c := d.Contract; c != nil {
+		out.Contract = &Contract{
+			AdditionalProhibitions: c.AdditionalProhibitions,
+			ContractTermsURL:       c.ContractTermsURL,
+			ContractURL:            c.ContractURL,
+			FeeMultiplier:          c.FeeMultiplier,
+			FeeType:                c.FeeType,
+		}
+		if c.SettlementSources != nil {
+			out.Contract.SettlementSources = make([]DiscoverySharedV0Schema, len(c.SettlementSources))
+			for i, s := range c.SettlementSources {
+				out.Contract.SettlementSources[i] = DiscoverySharedV0Schema{Name: s.Name, URL: s.URL}
+			}
+		}
+	}
+	if t := d.Timestamps; t != nil {
+		out.Timestamps = &Timestamps{CreatedAt: t.CreatedAt, PublishedAt: t.PublishedAt, UpdatedAt: t.UpdatedAt}
+	}
+	if c := d.Creators; c != nil {
+		out.Creators = &Creators{CreatedBy: c.CreatedBy, UpdatedBy: c.UpdatedBy}
+	}
+	return out
+}
+
+// ToDiscovery converts generated financial data to discovery.FinancialDataV0;
+// nil stays nil. It fails only if a contract count overflows int.
+func (f *Financial) ToDiscovery() (*discovery.FinancialDataV0, error) {
+	return f.toDiscovery("")
+}
+
+func (f *Financial) toDiscovery(path string) (*discovery.FinancialDataV0, error) {
+	if f == nil {
+		return nil, nil
+	}
+	volume24h, err := intPtr(path+"/volume_24h_contracts", f.Volume24HContracts)
+	if err != nil {
+		return nil, err
+	}
+	volumeTotal, err := intPtr(path+"/volume_total_contracts", f.VolumeTotalContracts)
+	if err != nil {
+		return nil, err
+	}
+	out := &discovery.FinancialDataV0{
+		Volume24hUSD:         f.Volume24HUsd,
+		VolumeTotalUSD:       f.VolumeTotalUsd,
+		LiquidityTotalUSD:    f.LiquidityTotalUsd,
+		Volume24hNative:      f.Volume24HNative,
+		VolumeTotalNative:    f.VolumeTotalNative,
+		LiquidityTotalNative: f.LiquidityTotalNative,
+		Volume24hContracts:   volume24h,
+		VolumeTotalContracts: volumeTotal,
+		Score:                f.Score,
+	}
+	if f.Currency != nil {
+		currency := string(*f.Currency)
+		out.Currency = &currency
+	}
+	return out, nil
+}
+
+// FromDiscoveryFinancial converts discovery.FinancialDataV0 to the generated
+// type; nil stays nil
+func FromDiscoveryFinancial(f *discovery.FinancialDataV0) *Financial {
+	if f == nil {
+		return nil
+	}
+	out := &Financial{
+		LiquidityTotalNative: f.LiquidityTotalNative,
+		LiquidityTotalUsd:    f.LiquidityTotalUSD,
+		Score:                f.Score,
+		Volume24HContracts:   int64Ptr(f.Volume24hContracts),
+		Volume24HNative:      f.Volume24hNative,
+		Volume24HUsd:         f.Volume24hUSD,
+		VolumeTotalContracts: int64Ptr(f.VolumeTotalContracts),
+		VolumeTotalNative:    f.VolumeTotalNative,
+		VolumeTotalUsd:       f.VolumeTotalUSD,
+	}
+	if f.Currency != nil {
+		currency := Currency(*f.Currency)
+		out.Currency = &currency
+	}
+	return out
+}
+
+// ToDiscovery converts generated series status to discovery.StatusDataV0;
+// nil stays nil
+func (s *StatusClass) ToDiscovery() *discovery.StatusDataV0 {
+	if s == nil {
+		return nil
+	}
+	return &discovery.StatusDataV0{
+		Archived:        s.Archived,
+		IsNew:           s.IsNew,
+		Featured:        s.Featured,
+		Restricted:      s.Restricted,
+		IsTemplate:      s.IsTemplate,
+		Competitive:     s.Competitive,
+		CommentsEnabled: s.CommentsEnabled,
+	}
+}
+
+// FromDiscoveryStatus converts discovery.StatusDataV0 to the generated type;
+// nil stays nil
+func FromDiscoveryStatus(s *discovery.StatusDataV0) *StatusClass {
+	if s == nil {
+		return nil
+	}
+	return &StatusClass{
+		Archived:        s.Archived,
+		CommentsEnabled: s.CommentsEnabled,
+		Competitive:     s.Competitive,
+		Featured:        s.Featured,
+		IsNew:           s.IsNew,
+		IsTemplate:      s.IsTemplate,
+		Restricted:      s.Restricted,
+	}
+}
+
+// ToDiscovery converts generated batch metadata to discovery.DiscoveryMetaV0;
+// nil stays nil. It fails only if a sequence or count overflows int.
+func (d *Discovery) ToDiscovery() (*discovery.DiscoveryMetaV0, error) {
+	return d.toDiscovery("")
+}
+
+func (d *Discovery) toDiscovery(path string) (*discovery.DiscoveryMetaV0, error) {
+	if d == nil {
+		return nil, nil
+	}
+	sequence, err := toInt(path+"/batch_sequence", d.BatchSequence)
+	if err != nil {
+		return nil, err
+	}
+	total, err := toInt(path+"/batch_total_count", d.BatchTotalCount)
+	if err != nil {
+		return nil, err
+	}
+	return &discovery.DiscoveryMetaV0{
+		BatchID:         d.BatchID,
+		BatchSequence:   sequence,
+		BatchTotalCount: total,
+		DiscoveryRunID:  d.DiscoveryRunID,
+	}, nil
+}
+
+// FromDiscoveryMeta converts discovery.DiscoveryMetaV0 to the generated type;
+// nil stays nil
+func FromDiscoveryMeta(d *discovery.DiscoveryMetaV0) *Discovery {
+	if d == nil {
+		return nil
+	}
+	return &Discovery{
+		BatchID:         d.BatchID,
+		BatchSequence:   int64(d.BatchSequence),
+		BatchTotalCount: int64(d.BatchTotalCount),
+		DiscoveryRunID:  d.DiscoveryRunID,
+	}
+}
+
+// ToDiscovery converts a generated event discovery payload to
+// discovery.EventDiscoveryPayloadV0
+func (p EventDiscoveryPayloadV0) ToDiscovery() (discovery.EventDiscoveryPayloadV0, error) {
+	meta, err := p.DiscoveryMeta.toDiscovery("/discovery_meta")
+	if err != nil {
+		return discovery.EventDiscoveryPayloadV0{}, err
+	}
+	return discovery.EventDiscoveryPayloadV0{
+		Event:         EventMetadataV0(p.Event).ToDiscovery(),
+		EventID:       p.EventID,
+		EventType:     discovery.EventType(p.EventType),
+		Timestamp:     p.Timestamp,
+		VenueID:       discovery.VenueID(p.VenueID),
+		DiscoveryMeta: meta,
+	}, nil
+}
+
+// FromDiscoveryEventPayload converts discovery.EventDiscoveryPayloadV0 to the
+// generated type
+func FromDiscoveryEventPayload(p discovery.EventDiscoveryPayloadV0) (EventDiscoveryPayloadV0, error) {
+	event, err := fromDiscoveryEventMetadata("/event", p.Event)
+	if err != nil {
+		return EventDiscoveryPayloadV0{}, err
+	}
+	return EventDiscoveryPayloadV0{
+		DiscoveryMeta: FromDiscoveryMeta(p.DiscoveryMeta),
+		Event:         EventClass(event),
+		EventID:       p.EventID,
+		EventType:     EventType(p.EventType),
+		Timestamp:     p.Timestamp,
+		VenueID:       VenueID(p.VenueID),
+	}, nil
+}
+
+// ToDiscovery converts a generated series discovery payload to
+// discovery.SeriesDiscoveryPayloadV0
+func (p SeriesDiscoveryPayloadV0) ToDiscovery() (discovery.SeriesDiscoveryPayloadV0, error) {
+	meta, err := p.DiscoveryMeta.toDiscovery("/discovery_meta")
+	if err != nil {
+		return discovery.SeriesDiscoveryPayloadV0{}, err
+	}
+	event, err := SeriesMetadataV0(p.Event).toDiscovery("/event")
+	if err != nil {
+		return discovery.SeriesDiscoveryPayloadV0{}, err
+	}
+	return discovery.SeriesDiscoveryPayloadV0{
+		Event:         event,
+		EventID:       p.EventID,
+		EventType:     discovery.EventType(p.EventType),
+		Timestamp:     p.Timestamp,
+		VenueID:       discovery.VenueID(p.VenueID),
+		DiscoveryMeta: meta,
+	}, nil
+}
+
+// FromDiscoverySeriesPayload converts discovery.SeriesDiscoveryPayloadV0 to
+// the generated type
+func FromDiscoverySeriesPayload(p discovery.SeriesDiscoveryPayloadV0) (SeriesDiscoveryPayloadV0, error) {
+	event, err := fromDiscoverySeriesMetadata("/event", p.Event)
+	if err != nil {
+		return SeriesDiscoveryPayloadV0{}, err
+	}
+	return SeriesDiscoveryPayloadV0{
+		DiscoveryMeta: FromDiscoveryMeta(p.DiscoveryMeta),
+		Event:         SeriesDiscoveryPayloadV0Event(event),
+		EventID:       p.EventID,
+		EventType:     EventType(p.EventType),
+		Timestamp:     p.Timestamp,
+		VenueID:       VenueID(p.VenueID),
+	}, nil
+}
+
+// DiscoveryPayload returns the raw.events.v0 payload as
+// discovery.EventDiscoveryPayloadV0 and validates it with
+// discovery.CheckEventDiscoveryPayload. Fields missing from the payload are
+// left zero and reported by the validation; on a failed validation the
+// converted payload is returned with the *discovery.ValidationReport.
+func (r RawEventsDiscoveryV0) DiscoveryPayload() (discovery.EventDiscoveryPayloadV0, error) {
+	meta, err := r.Payload.DiscoveryMeta.toDiscovery("/discovery_meta")
+	if err != nil {
+		return discovery.EventDiscoveryPayloadV0{}, err
+	}
+	p := discovery.EventDiscoveryPayloadV0{DiscoveryMeta: meta}
+	if r.Payload.Event != nil {
+		p.Event = EventMetadataV0(*r.Payload.Event).ToDiscovery()
+	}
+	p.EventID, p.EventType, p.Timestamp, p.VenueID = rawPayloadHeader(r.Payload.EventID, r.Payload.EventType, r.Payload.Timestamp, r.Payload.VenueID)
+	return p, discovery.CheckEventDiscoveryPayload(p).Err()
+}
+
+// DiscoveryPayload returns the raw.series.v0 payload as
+// discovery.SeriesDiscoveryPayloadV0 and validates it with
+// discovery.CheckSeriesDiscoveryPayload, like
+// RawEventsDiscoveryV0.DiscoveryPayload
+func (r RawSeriesDiscoveryV0) DiscoveryPayload() (discovery.SeriesDiscoveryPayloadV0, error) {
+	meta, err := r.Payload.DiscoveryMeta.toDiscovery("/discovery_meta")
+	if err != nil {
+		return discovery.SeriesDiscoveryPayloadV0{}, err
+	}
+	p := discovery.SeriesDiscoveryPayloadV0{DiscoveryMeta: meta}
+	if r.Payload.Event != nil {
+		if p.Event, err = SeriesMetadataV0(*r.Payload.Event).toDiscovery("/event"); err != nil {
+			return discovery.SeriesDiscoveryPayloadV0{}, err
+		}
+	}
+	p.EventID, p.EventType, p.Timestamp, p.VenueID = rawPayloadHeader(r.Payload.EventID, r.Payload.EventType, r.Payload.Timestamp, r.Payload.VenueID)
+	return p, discovery.CheckSeriesDiscoveryPayload(p).Err()
+}
+
+func rawPayloadHeader(eventID *string, eventType *EventType, timestamp *time.Time, venueID *VenueID) (id string, typ discovery.EventType, ts time.Time, venue discovery.VenueID) {
+	if eventID != nil {
+		id = *eventID
+	}
+	if eventType != nil {
+		typ = discovery.EventType(*eventType)
+	}
+	if timestamp != nil {
+		ts = *timestamp
+	}
+	if venueID != nil {
+		venue = discovery.VenueID(*venueID)
+	}
+	return id, typ, ts, venue
+}
+
+func requiredBool(path string, v *bool) (bool, error) {
+	if v == nil {
+		return false, ConversionError{Field: path, Message: "is required"}
+	}
+	return *v, nil
+}
+
+func toInt(path string, v int64) (int, error) {
+	if int64(int(v)) != v {
+		return 0, ConversionError{Field: path, Message: fmt.Sprintf("%d overflows int", v)}
+	}
+	return int(v), nil
+}
+
+func intPtr(path string, v *int64) (*int, error) {
+	if v == nil {
+		return nil, nil
+	}
+	n, err := toInt(path, *v)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func int64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := int64(*v)
+	return &n
+}
+
+// VenueCategory returns the raw.categories.v0 payload as a
+// discovery.VenueCategoryV0 for the envelope's venue
+func (r RawCategoriesDiscoveryV0) VenueCategory() (discovery.VenueCategoryV0, error) {
+	data, err := json.Marshal(r.Payload)
+	if err != nil {
+		return discovery.VenueCategoryV0{}, fmt.Errorf("raw categories: failed to encode payload: %w", err)
+	}
+	return discovery.ParseVenueCategory(discovery.VenueID(r.Envelope.VenueID), data)
+}
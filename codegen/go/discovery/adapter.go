@@ -0,0 +1,599 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// VenueAdapter maps a venue's native discovery JSON onto the canonical
+// metadata. Fields the adapter does not map are copied into ExtraMetadata and
+// their names returned, sorted. So are nested arrays it maps only in part,
+// such as markets of which only the identifiers are kept, and USD amounts
+// with fractions of a cent, which the schema does not allow. seenAt becomes
+// both discovered_at and last_seen. The metadata is validated before it is
+// returned; on failure it is returned along with the *ValidationReport.
+type VenueAdapter interface {
+	Venue() VenueID
+	AdaptEvent(data []byte, seenAt time.Time) (EventMetadataV0, []string, error)
+	AdaptSeries(data []byte, seenAt time.Time) (SeriesMetadataV0, []string, error)
+}
+
+// AdapterError reports a native field with an unexpected JSON type, or a
+// payload that is not a JSON object
+type AdapterError struct {
+	Venue   VenueID
+	Kind    DiscoveryKind
+	Field   string
+	Message string
+}
+
+func (e *AdapterError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s %s: %s", e.Venue, e.Kind, e.Message)
+	}
+	return fmt.Sprintf("%s %s: field %q: %s", e.Venue, e.Kind, e.Field, e.Message)
+}
+
+// AdapterFor returns the adapter for a venue
+func AdapterFor(venue VenueID) (VenueAdapter, bool) {
+	switch venue {
+	case VenueIDPolymarket:
+		return polymarketAdapter{}, true
+	case VenueIDKalshi:
+		return kalshiAdapter{}, true
+	default:
+		return nil, false
+	}
+}
+
+// polymarketAdapter maps Gamma API events and series. Field names are
+// accepted in both the API's camelCase and snake_case.
+type polymarketAdapter struct{}
+
+func (polymarketAdapter) Venue() VenueID { return VenueIDPolymarket }
+
+func (polymarketAdapter) AdaptEvent(data []byte, seenAt time.Time) (EventMetadataV0, []string, error) {
+	o, err := decodeNative(VenueIDPolymarket, DiscoveryKindEvent, data)
+	if err != nil {
+		return EventMetadataV0{}, nil, err
+	}
+	m := EventMetadataV0{
+		Kind:         DiscoveryKindEvent,
+		VenueID:      VenueIDPolymarket,
+		EventID:      o.id("id"),
+		Title:        o.str("title", "name"),
+		Description:  o.optStr("description"),
+		Category:     o.optStr("category"),
+		StartDate:    o.time("startDate", "start_date"),
+		EndDate:      o.time("endDate", "end_date"),
+		Tags:         o.tags("tags"),
+		DiscoveredAt: seenAt,
+		LastSeen:     seenAt,
+	}
+	m.Active, m.Closed = o.status("active", "closed", "status")
+	m.ParentSeriesID, m.ParentSeriesTitle = o.parentSeries("series")
+	if markets := o.ids("markets", "id", "conditionId"); len(markets) > 0 {
+		m.Relationships = &RelationshipsV0{InstrumentIDs: markets}
+	}
+	return finishEvent(o, m)
+}
+
+func (polymarketAdapter) AdaptSeries(data []byte, seenAt time.Time) (SeriesMetadataV0, []string, error) {
+	o, err := decodeNative(VenueIDPolymarket, DiscoveryKindSeries, data)
+	if err != nil {
+		return SeriesMetadataV0{}, nil, err
+	}
+	m := SeriesMetadataV0{
+		Kind:          DiscoveryKindSeries,
+		VenueID:       VenueIDPolymarket,
+		EventID:       o.id("id"),
+		Title:         o.str("title", "name"),
+		Description:   o.optStr("description"),
+		Category:      o.optStr("category"),
+		Tags:          o.tags("tags"),
+		ChildEventIDs: o.ids("events", "id"),
+		DiscoveredAt:  seenAt,
+		LastSeen:      seenAt,
+	}
+	m.Active, m.Closed = o.status("active", "closed", "status")
+	seriesData := SeriesDataV0{
+		Ticker:     o.optStr("ticker"),
+		Slug:       o.optStr("slug"),
+		Subtitle:   o.optStr("subtitle"),
+		SeriesType: o.optStr("seriesType", "series_type"),
+		Recurrence: o.optStr("recurrence"),
+		ImageURL:   o.optStr("image"),
+		IconURL:    o.optStr("icon"),
+		Layout:     o.optStr("layout"),
+	}
+	financial := FinancialDataV0{
+		Volume24hUSD:      o.usd("volume24hr"),
+		VolumeTotalUSD:    o.usd("volume"),
+		LiquidityTotalUSD: o.usd("liquidity"),
+		Score:             o.float("score"),
+	}
+	if financial != (FinancialDataV0{}) {
+		currency := string(CurrencyUSD)
+		financial.Currency = &currency
+		seriesData.Financial = &financial
+	}
+	status := StatusDataV0{
+		Archived:        o.optBool("archived"),
+		IsNew:           o.optBool("new", "isNew"),
+		Featured:        o.optBool("featured"),
+		Restricted:      o.optBool("restricted"),
+		IsTemplate:      o.optBool("isTemplate"),
+		Competitive:     o.optStr("competitive"),
+		CommentsEnabled: o.optBool("commentsEnabled"),
+	}
+	if status != (StatusDataV0{}) {
+		seriesData.Status = &status
+	}
+	timestamps := TimestampDataV0{
+		PublishedAt: o.time("publishedAt", "published_at"),
+		CreatedAt:   o.time("createdAt", "created_at"),
+		UpdatedAt:   o.time("updatedAt", "updated_at"),
+	}
+	if timestamps != (TimestampDataV0{}) {
+		seriesData.Timestamps = &timestamps
+	}
+	creators := CreatorDataV0{CreatedBy: o.optStr("createdBy"), UpdatedBy: o.optStr("updatedBy")}
+	if creators != (CreatorDataV0{}) {
+		seriesData.Creators = &creators
+	}
+	if !isEmptyJSON(seriesData) {
+		m.SeriesData = &seriesData
+	}
+	return finishSeries(o, m)
+}
+
+// kalshiAdapter maps Trade API events and series
+type kalshiAdapter struct{}
+
+func (kalshiAdapter) Venue() VenueID { return VenueIDKalshi }
+
+func (kalshiAdapter) AdaptEvent(data []byte, seenAt time.Time) (EventMetadataV0, []string, error) {
+	o, err := decodeNative(VenueIDKalshi, DiscoveryKindEvent, data)
+	if err != nil {
+		return EventMetadataV0{}, nil, err
+	}
+	m := EventMetadataV0{
+		Kind:           DiscoveryKindEvent,
+		VenueID:        VenueIDKalshi,
+		EventID:        o.id("event_ticker", "ticker"),
+		Title:          o.str("title"),
+		Category:       o.optStr("category"),
+		StartDate:      o.time("start_date"),
+		EndDate:        o.time("end_date", "strike_date"),
+		ParentSeriesID: o.optStr("series_ticker"),
+		Tags:           o.tags("tags"),
+		DiscoveredAt:   seenAt,
+		LastSeen:       seenAt,
+	}
+	m.Active, m.Closed = o.status("active", "closed", "status")
+	if markets := o.ids("markets", "ticker"); len(markets) > 0 {
+		m.Relationships = &RelationshipsV0{InstrumentIDs: markets}
+	}
+	return finishEvent(o, m)
+}
+
+func (kalshiAdapter) AdaptSeries(data []byte, seenAt time.Time) (SeriesMetadataV0, []string, error) {
+	o, err := decodeNative(VenueIDKalshi, DiscoveryKindSeries, data)
+	if err != nil {
+		return SeriesMetadataV0{}, nil, err
+	}
+	ticker := o.id("ticker", "series_ticker")
+	m := SeriesMetadataV0{
+		Kind:          DiscoveryKindSeries,
+		VenueID:       VenueIDKalshi,
+		EventID:       ticker,
+		Title:         o.str("title"),
+		Category:      o.optStr("category"),
+		Tags:          o.tags("tags"),
+		ChildEventIDs: o.ids("events", "event_ticker"),
+		DiscoveredAt:  seenAt,
+		LastSeen:      seenAt,
+	}
+	m.Active, m.Closed = o.status("active", "closed", "status")
+	seriesData := SeriesDataV0{Recurrence: o.optStr("frequency")}
+	if ticker != "" {
+		seriesData.Ticker = &ticker
+	}
+	contract := ContractDataV0{
+		ContractURL:            o.optStr("contract_url"),
+		ContractTermsURL:       o.optStr("contract_terms_url"),
+		FeeType:                o.optStr("fee_type"),
+		FeeMultiplier:          o.float("fee_multiplier"),
+		AdditionalProhibitions: o.strs("additional_prohibitions"),
+		SettlementSources:      o.settlementSources("settlement_sources"),
+	}
+	if !isEmptyJSON(contract) {
+		seriesData.Contract = &contract
+	}
+	timestamps := TimestampDataV0{
+		CreatedAt: o.time("created_at"),
+		UpdatedAt: o.time("updated_at", "last_updated_ts"),
+	}
+	if timestamps != (TimestampDataV0{}) {
+		seriesData.Timestamps = &timestamps
+	}
+	if !isEmptyJSON(seriesData) {
+		m.SeriesData = &seriesData
+	}
+	return finishSeries(o, m)
+}
+
+func finishEvent(o *nativeObject, m EventMetadataV0) (EventMetadataV0, []string, error) {
+	if o.err != nil {
+		return EventMetadataV0{}, nil, o.err
+	}
+	var unmapped []string
+	m.ExtraMetadata, unmapped = o.extra()
+	return m, unmapped, CheckEventMetadata(m).Err()
+}
+
+func finishSeries(o *nativeObject, m SeriesMetadataV0) (SeriesMetadataV0, []string, error) {
+	if o.err != nil {
+		return SeriesMetadataV0{}, nil, o.err
+	}
+	var unmapped []string
+	m.ExtraMetadata, unmapped = o.extra()
+	return m, unmapped, CheckSeriesMetadata(m).Err()
+}
+
+// isEmptyJSON reports whether v encodes to an empty JSON object, i.e. every
+// field is nil or empty
+func isEmptyJSON(v any) bool {
+	data, err := json.Marshal(v)
+	return err == nil && string(data) == "{}"
+}
+
+// nativeObject reads fields from a decoded native JSON object, remembering
+// which ones were consumed. The first type mismatch is kept in err and later
+// reads return zero values.
+type nativeObject struct {
+	venue  VenueID
+	kind   DiscoveryKind
+	fields map[string]any
+	used   map[string]bool
+	kept   map[string]bool // consumed, but only in part
+	err    error
+}
+
+func decodeNative(venue VenueID, kind DiscoveryKind, data []byte) (*nativeObject, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		msg := "payload must be a JSON object"
+		if err != nil {
+			msg = fmt.Sprintf("failed to parse payload: %v", err)
+		}
+		return nil, &AdapterError{Venue: venue, Kind: kind, Message: msg}
+	}
+	return &nativeObject{venue: venue, kind: kind, fields: fields, used: make(map[string]bool), kept: make(map[string]bool)}, nil
+}
+
+// get returns the first of keys that is present and not null. Every present
+// key is marked as consumed.
+func (o *nativeObject) get(keys ...string) (string, any, bool) {
+	if o.err != nil {
+		return "", nil, false
+	}
+	var key string
+	var value any
+	found := false
+	for _, k := range keys {
+		v, ok := o.fields[k]
+		if !ok {
+			continue
+		}
+		o.used[k] = true
+		if v != nil && !found {
+			key, value, found = k, v, true
+		}
+	}
+	return key, value, found
+}
+
+func (o *nativeObject) fail(key, format string, args ...any) {
+	if o.err == nil {
+		o.err = &AdapterError{Venue: o.venue, Kind: o.kind, Field: key, Message: fmt.Sprintf(format, args...)}
+	}
+}
+
+func (o *nativeObject) str(keys ...string) string {
+	if s := o.optStr(keys...); s != nil {
+		return *s
+	}
+	return ""
+}
+
+// optStr reads a string field; empty strings read as absent
+func (o *nativeObject) optStr(keys ...string) *string {
+	key, v, ok := o.get(keys...)
+	if !ok {
+		return nil
+	}
+	s, isString := v.(string)
+	if !isString {
+		o.fail(key, "want string, got %T", v)
+		return nil
+	}
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// id reads an identifier, which venues send as either a string or a number
+func (o *nativeObject) id(keys ...string) string {
+	key, v, ok := o.get(keys...)
+	if !ok {
+		return ""
+	}
+	if id, ok := idString(v); ok {
+		return id
+	}
+	o.fail(key, "want string or number, got %T", v)
+	return ""
+}
+
+func idString(v any) (string, bool) {
+	switch id := v.(type) {
+	case string:
+		return id, true
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func (o *nativeObject) optBool(keys ...string) *bool {
+	key, v, ok := o.get(keys...)
+	if !ok {
+		return nil
+	}
+	b, isBool := v.(bool)
+	if !isBool {
+		o.fail(key, "want boolean, got %T", v)
+		return nil
+	}
+	return &b
+}
+
+// float reads a number, also accepting numeric strings as the Gamma API
+// sends for some volumes
+func (o *nativeObject) float(keys ...string) *float64 {
+	key, v, ok := o.get(keys...)
+	if !ok {
+		return nil
+	}
+	switch n := v.(type) {
+	case float64:
+		return &n
+	case string:
+		if f, err := strconv.ParseFloat(n, 64); err == nil {
+			return &f
+		}
+	}
+	o.fail(key, "want number, got %v", v)
+	return nil
+}
+
+// count reads a non-negative whole number
+func (o *nativeObject) count(keys ...string) *int {
+	key, v, ok := o.get(keys...)
+	if !ok {
+		return nil
+	}
+	f, isNumber := v.(float64)
+	if !isNumber || f < 0 || f != math.Trunc(f) || f > math.MaxInt32 {
+		o.fail(key, "want non-negative integer, got %v", v)
+		return nil
+	}
+	n := int(f)
+	return &n
+}
+
+// usd reads a USD amount in whole cents. An amount with a fraction of a cent
+// is not mapped, so it is kept unrounded in ExtraMetadata.
+func (o *nativeObject) usd(keys ...string) *float64 {
+	f := o.float(keys...)
+	if f == nil {
+		return nil
+	}
+	cents := *f * 100
+	if math.Abs(cents-math.Round(cents)) > 1e-9*math.Max(1, math.Abs(cents)) {
+		for _, k := range keys {
+			delete(o.used, k)
+		}
+		return nil
+	}
+	return f
+}
+
+func (o *nativeObject) time(keys ...string) *time.Time {
+	key, v, ok := o.get(keys...)
+	if !ok {
+		return nil
+	}
+	s, isString := v.(string)
+	if !isString {
+		o.fail(key, "want RFC 3339 timestamp, got %T", v)
+		return nil
+	}
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		o.fail(key, "want RFC 3339 timestamp, got %q", s)
+		return nil
+	}
+	return &t
+}
+
+// status reads the active and closed flags, falling back to a status string
+// for venues that only send one
+func (o *nativeObject) status(activeKey, closedKey, statusKey string) (active, closed *bool) {
+	active, closed = o.optBool(activeKey), o.optBool(closedKey)
+	status := o.optStr(statusKey)
+	if status == nil || (active != nil && closed != nil) {
+		return active, closed
+	}
+	var isActive, isClosed bool
+	switch *status {
+	case "active", "open", "initialized", "unopened":
+		isActive, isClosed = true, false
+	case "closed", "settled", "determined", "finalized", "resolved", "inactive":
+		isActive, isClosed = false, true
+	default:
+		return active, closed
+	}
+	if active == nil {
+		active = &isActive
+	}
+	if closed == nil {
+		closed = &isClosed
+	}
+	return active, closed
+}
+
+func (o *nativeObject) array(key string) []any {
+	_, v, ok := o.get(key)
+	if !ok {
+		return nil
+	}
+	items, isArray := v.([]any)
+	if !isArray {
+		o.fail(key, "want array, got %T", v)
+		return nil
+	}
+	return items
+}
+
+func (o *nativeObject) strs(key string) []string {
+	items := o.array(key)
+	var out []string
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			o.fail(key, "want array of strings, got %T item", item)
+			return nil
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// ids reads an array of identifiers, or of objects whose identifier is the
+// first present of idKeys. Objects carrying more than their identifier are
+// kept.
+func (o *nativeObject) ids(key string, idKeys ...string) []string {
+	var out []string
+	for _, item := range o.array(key) {
+		if obj, ok := item.(map[string]any); ok {
+			for _, k := range idKeys {
+				if id, ok := idString(obj[k]); ok && id != "" {
+					item = id
+					break
+				}
+			}
+			if len(obj) > 1 {
+				o.kept[key] = true
+			}
+		}
+		id, ok := idString(item)
+		if !ok {
+			o.fail(key, "want identifiers, got %T item", item)
+			return nil
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// tags reads an array of tag strings, or of tag objects with a slug or label
+func (o *nativeObject) tags(key string) []string {
+	return o.ids(key, "slug", "label")
+}
+
+// parentSeries reads the first series of a Polymarket event's series array.
+// The array is kept unless it is that one series' id and title.
+func (o *nativeObject) parentSeries(key string) (id, title *string) {
+	items := o.array(key)
+	if len(items) == 0 {
+		return nil, nil
+	}
+	series, ok := items[0].(map[string]any)
+	if !ok {
+		o.fail(key, "want array of series objects, got %T item", items[0])
+		return nil, nil
+	}
+	if s, ok := idString(series["id"]); ok && s != "" {
+		id = &s
+	}
+	if s, ok := series["title"].(string); ok && s != "" {
+		title = &s
+	}
+	if !onlyKeys(series, "id", "title") || len(items) > 1 {
+		o.kept[key] = true
+	}
+	return id, title
+}
+
+func (o *nativeObject) settlementSources(key string) []SettlementSourceV0 {
+	var out []SettlementSourceV0
+	for _, item := range o.array(key) {
+		obj, ok := item.(map[string]any)
+		name, hasName := obj["name"].(string)
+		if !ok || !hasName {
+			o.fail(key, "want objects with a name, got %v", item)
+			return nil
+		}
+		source := SettlementSourceV0{Name: name}
+		if url, ok := obj["url"].(string); ok && url != "" {
+			source.URL = &url
+		}
+		if !onlyKeys(obj, "name", "url") {
+			o.kept[key] = true
+		}
+		out = append(out, source)
+	}
+	return out
+}
+
+// onlyKeys reports whether obj has no keys other than keys
+func onlyKeys(obj map[string]any, keys ...string) bool {
+	n := 0
+	for _, k := range keys {
+		if _, ok := obj[k]; ok {
+			n++
+		}
+	}
+	return n == len(obj)
+}
+
+// extra returns the fields that were not consumed, or consumed only in part,
+// and their sorted names
+func (o *nativeObject) extra() (map[string]any, []string) {
+	var names []string
+	for k := range o.fields {
+		if !o.used[k] || o.kept[k] {
+			names = append(names, k)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+	extra := make(map[string]any, len(names))
+	for _, k := range names {
+		extra[k] = o.fields[k]
+	}
+	return extra, names
+}
@@ -0,0 +1,217 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var adapterSeenAt = time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC)
+
+func mustAdapter(t *testing.T, venue VenueID) VenueAdapter {
+	t.Helper()
+	adapter, ok := AdapterFor(venue)
+	if !ok {
+		t.Fatalf("AdapterFor(%s) not found", venue)
+	}
+	return adapter
+}
+
+// rawExamplePayload returns the native payload of a raw.events.v0/raw.series.v0 example
+func rawExamplePayload(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "schemas", "examples", name))
+	if err != nil {
+		t.Fatalf("Failed to load example %s: %v", name, err)
+	}
+	var raw struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	return raw.Payload
+}
+
+// The adapters agree with the reference mappings in mapping_test.go
+func TestAdapters_MatchReferenceMapping(t *testing.T) {
+	polymarket := PolymarketSeriesAPI{
+		ID: "10244", Title: "Concacaf", Ticker: "CONCACAF", Slug: "concacaf", Subtitle: "Soccer Championship",
+		SeriesType: "single", Recurrence: "annual", Category: "Sports",
+		Image: "https://polymarket.com/image.jpg", Icon: "https://polymarket.com/icon.png",
+		Volume24hr: 125000.50, Volume: 2500000.75, Active: true,
+		CreatedAt: "2025-09-03T03:07:56.295896Z", UpdatedAt: "2025-11-03T21:01:11.954948Z",
+	}
+	kalshi := KalshiSeriesAPI{
+		Ticker: "PRES24", Title: "2024 Presidential Election", Category: "Politics", Frequency: "quadrennial",
+		Tags:        []string{"politics", "election"},
+		ContractURL: "https://kalshi.com/markets/PRES24", ContractTermsURL: "https://kalshi.com/terms/PRES24",
+		FeeType: "percentage", AdditionalProhibitions: []string{"insider_trading"},
+		SettlementSources: []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		}{{Name: "Associated Press", URL: "https://www.ap.org"}},
+		Active:    true,
+		CreatedAt: "2023-11-01T00:00:00Z", UpdatedAt: "2025-11-04T11:30:00Z",
+	}
+
+	tests := []struct {
+		venue  VenueID
+		native any
+		want   SeriesMetadataV0
+	}{
+		{VenueIDPolymarket, polymarket, mapPolymarketToCanonical(polymarket)},
+		{VenueIDKalshi, kalshi, mapKalshiToCanonical(kalshi)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.venue), func(t *testing.T) {
+			data, err := json.Marshal(tt.native)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, unmapped, err := mustAdapter(t, tt.venue).AdaptSeries(data, adapterSeenAt)
+			if err != nil {
+				t.Fatalf("AdaptSeries() error = %v", err)
+			}
+			if len(unmapped) != 0 {
+				t.Errorf("unmapped = %v, want none", unmapped)
+			}
+			tt.want.DiscoveredAt, tt.want.LastSeen = adapterSeenAt, adapterSeenAt
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("AdaptSeries() =\n%s\nwant\n%s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestAdapters_RawExamples(t *testing.T) {
+	tests := []struct {
+		example      string
+		venue        VenueID
+		series       bool
+		wantID       string
+		wantUnmapped []string
+	}{
+		{"raw.events.kalshi.example.json", VenueIDKalshi, false, "PRES24", []string{"markets_count", "mutually_exclusive", "settle_date", "sub_title", "volume"}},
+		{"raw.events.polymarket.example.json", VenueIDPolymarket, false, "event_12345", []string{"image_url", "resolution_source", "volume"}},
+		{"raw.series.kalshi.example.json", VenueIDKalshi, true, "NFL24", []string{"event_count", "settlement_source"}},
+		{"raw.series.polymarket.example.json", VenueIDPolymarket, true, "series_nfl_2024", []string{"end_date", "start_date"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.example, func(t *testing.T) {
+			data := rawExamplePayload(t, tt.example)
+			adapter := mustAdapter(t, tt.venue)
+			var (
+				id       string
+				active   *bool
+				extra    map[string]any
+				unmapped []string
+				err      error
+			)
+			if tt.series {
+				var m SeriesMetadataV0
+				m, unmapped, err = adapter.AdaptSeries(data, adapterSeenAt)
+				id, active, extra = m.EventID, m.Active, m.ExtraMetadata
+			} else {
+				var m EventMetadataV0
+				m, unmapped, err = adapter.AdaptEvent(data, adapterSeenAt)
+				id, active, extra = m.EventID, m.Active, m.ExtraMetadata
+			}
+			if err != nil {
+				t.Fatalf("adapt error = %v", err)
+			}
+			if id != tt.wantID || active == nil || !*active {
+				t.Errorf("event_id = %q, active = %v", id, active)
+			}
+			if !reflect.DeepEqual(unmapped, tt.wantUnmapped) {
+				t.Errorf("unmapped = %v, want %v", unmapped, tt.wantUnmapped)
+			}
+			for _, name := range unmapped {
+				if _, ok := extra[name]; !ok {
+					t.Errorf("extra_metadata missing %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestPolymarketAdapter_Event(t *testing.T) {
+	data := []byte(`{
+		"id": 903, "title": "Fed decision in December?", "startDate": "2025-10-01T00:00:00Z",
+		"active": true, "closed": false, "featured": true,
+		"tags": [{"id": "2", "label": "Economy", "slug": "economy"}],
+		"series": [{"id": "77", "title": "Fed Decisions"}],
+		"markets": [{"id": "m1", "question": "Cut?"}, {"id": "m2"}]
+	}`)
+	m, unmapped, err := mustAdapter(t, VenueIDPolymarket).AdaptEvent(data, adapterSeenAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.EventID != "903" || m.ParentSeriesID == nil || *m.ParentSeriesID != "77" || *m.ParentSeriesTitle != "Fed Decisions" {
+		t.Errorf("AdaptEvent() = %+v", m)
+	}
+	if !reflect.DeepEqual(m.Tags, []string{"economy"}) || !reflect.DeepEqual(m.Relationships.InstrumentIDs, []string{"m1", "m2"}) {
+		t.Errorf("tags = %v, relationships = %+v", m.Tags, m.Relationships)
+	}
+	// Market and tag objects carry more than the identifiers that were mapped
+	if !reflect.DeepEqual(unmapped, []string{"featured", "markets", "tags"}) || m.ExtraMetadata["featured"] != true {
+		t.Errorf("unmapped = %v, extra = %v", unmapped, m.ExtraMetadata)
+	}
+	if markets, _ := m.ExtraMetadata["markets"].([]any); len(markets) != 2 {
+		t.Errorf("extra markets = %v, want the native objects", m.ExtraMetadata["markets"])
+	}
+}
+
+func TestPolymarketAdapter_SeriesKeepsUnmappedDetail(t *testing.T) {
+	data := []byte(`{
+		"id": "10244", "title": "Concacaf", "active": true, "closed": false,
+		"events": [{"id": "e1", "slug": "final"}, {"id": "e2"}],
+		"volume": 2500000.75, "volume24hr": 1234.5678
+	}`)
+	m, unmapped, err := mustAdapter(t, VenueIDPolymarket).AdaptSeries(data, adapterSeenAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m.ChildEventIDs, []string{"e1", "e2"}) {
+		t.Errorf("child_event_ids = %v", m.ChildEventIDs)
+	}
+	financial := m.SeriesData.Financial
+	if financial.Volume24hUSD != nil || financial.VolumeTotalUSD == nil || *financial.VolumeTotalUSD != 2500000.75 {
+		t.Errorf("financial = %+v, want only whole-cent volumes mapped", financial)
+	}
+	if !reflect.DeepEqual(unmapped, []string{"events", "volume24hr"}) || m.ExtraMetadata["volume24hr"] != 1234.5678 {
+		t.Errorf("unmapped = %v, extra = %v", unmapped, m.ExtraMetadata)
+	}
+	if err := CheckSeriesMetadata(m).Err(); err != nil {
+		t.Errorf("adapted series invalid: %v", err)
+	}
+}
+
+func TestAdapters_Errors(t *testing.T) {
+	polymarket := mustAdapter(t, VenueIDPolymarket)
+
+	var adapterErr *AdapterError
+	if _, _, err := polymarket.AdaptEvent([]byte(`{"id": "1", "title": 7}`), adapterSeenAt); !errors.As(err, &adapterErr) || adapterErr.Field != "title" {
+		t.Errorf("wrong type error = %v", err)
+	}
+	if _, _, err := polymarket.AdaptSeries([]byte(`[1]`), adapterSeenAt); !errors.As(err, &adapterErr) {
+		t.Errorf("non-object error = %v", err)
+	}
+
+	// Maps but fails validation: no title, and no active flag or status
+	m, _, err := mustAdapter(t, VenueIDKalshi).AdaptEvent([]byte(`{"event_ticker": "X"}`), adapterSeenAt)
+	var report *ValidationReport
+	if !errors.As(err, &report) || len(report.Errors()) != 3 || m.EventID != "X" {
+		t.Errorf("AdaptEvent() = %+v, %v", m, err)
+	}
+
+	if _, ok := AdapterFor("unknown"); ok {
+		t.Error("AdapterFor(unknown) found an adapter")
+	}
+}
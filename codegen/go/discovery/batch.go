@@ -0,0 +1,314 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNoDiscoveryMeta is returned for payloads without discovery_meta, which
+// cannot be assigned to a run
+var ErrNoDiscoveryMeta = errors.New("payload has no discovery_meta")
+
+// ErrRunFinalized is returned for payloads that arrive after their run was
+// closed or timed out
+var ErrRunFinalized = errors.New("discovery run already finalized")
+
+// IssueKind classifies a problem found while assembling a batch
+type IssueKind string
+
+const (
+	IssueDuplicate     IssueKind = "duplicate"      // batch_sequence received twice; the first copy is kept
+	IssueMissing       IssueKind = "missing"        // batch_sequence never received
+	IssueOverrun       IssueKind = "overrun"        // batch_sequence greater than batch_total_count
+	IssueTotalMismatch IssueKind = "total_mismatch" // batch_total_count differs between items of a batch
+	IssueTimeout       IssueKind = "timeout"        // run idle longer than the assembler timeout
+)
+
+// BatchIssue is a problem found in one batch of a run. Duplicates and
+// overruns are also returned as errors by Add.
+type BatchIssue struct {
+	Kind     IssueKind
+	RunID    string
+	BatchID  string
+	Sequence int
+	Message  string
+}
+
+func (i *BatchIssue) Error() string {
+	if i.BatchID == "" {
+		return fmt.Sprintf("run %s: %s: %s", i.RunID, i.Kind, i.Message)
+	}
+	return fmt.Sprintf("run %s batch %s: %s: %s", i.RunID, i.BatchID, i.Kind, i.Message)
+}
+
+// RunStatus is the outcome of a finalized run
+type RunStatus string
+
+const (
+	// RunComplete means every batch of the run arrived in full. Only then is it
+	// safe to expire entities missing from the run.
+	RunComplete RunStatus = "complete"
+	// RunPartial means batches are incomplete or inconsistent, or the run timed out
+	RunPartial RunStatus = "partial"
+)
+
+// EntityRef identifies a discovered event or series
+type EntityRef struct {
+	VenueID VenueID       `json:"venue_id"`
+	Kind    DiscoveryKind `json:"kind"`
+	EventID string        `json:"event_id"`
+}
+
+// BatchResult summarises one batch of a finalized run
+type BatchResult struct {
+	BatchID  string
+	Total    int
+	Received int
+	Missing  []int
+}
+
+// RunResult is emitted once per run when it is closed or times out
+type RunResult struct {
+	RunID     string
+	Status    RunStatus
+	Batches   []BatchResult // sorted by batch ID
+	Issues    []BatchIssue
+	Seen      []EntityRef // sorted by venue, kind and event ID
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// SafeToExpire reports whether entities absent from Seen may be treated as expired
+func (r RunResult) SafeToExpire() bool {
+	return r.Status == RunComplete
+}
+
+// AssemblerConfig configures an Assembler
+type AssemblerConfig struct {
+	// Timeout finalizes a run as partial when no payload has arrived for it
+	// for this long. Zero disables timeouts.
+	Timeout time.Duration
+	// Retention is how long the IDs of finalized runs are remembered so late
+	// payloads are rejected with ErrRunFinalized. Older IDs are pruned when a
+	// run is finalized and by Sweep; a payload for a pruned run starts a new
+	// run. Zero means DefaultRetention.
+	Retention time.Duration
+	// Now returns the current time; defaults to time.Now
+	Now func() time.Time
+}
+
+// DefaultRetention is the AssemblerConfig.Retention used when none is set
+const DefaultRetention = 24 * time.Hour
+
+// Assembler groups discovery payloads by discovery_run_id and batch_id and
+// reports whether each run arrived in full. It is safe for concurrent use.
+//
+// The raw envelopes' metadata.discovery_page is not used: it is the venue
+// API's pagination page, which is optional and unrelated to batch numbering,
+// so batch_sequence and batch_total_count are the only completeness signal.
+type Assembler struct {
+	cfg AssemblerConfig
+
+	mu   sync.Mutex
+	runs map[string]*runState
+	// finalized maps the ID of each finalized run to when it was finalized
+	finalized map[string]time.Time
+}
+
+type runState struct {
+	id        string
+	batches   map[string]*batchState
+	issues    []BatchIssue
+	seen      map[EntityRef]bool
+	startedAt time.Time
+	lastAt    time.Time
+}
+
+type batchState struct {
+	total    int
+	received map[int]bool
+}
+
+// NewAssembler creates an assembler with no runs
+func NewAssembler(cfg AssemblerConfig) *Assembler {
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = DefaultRetention
+	}
+	return &Assembler{cfg: cfg, runs: make(map[string]*runState), finalized: make(map[string]time.Time)}
+}
+
+// AddEvent adds an event discovery payload to its run
+func (a *Assembler) AddEvent(p EventDiscoveryPayloadV0) error {
+	return a.add(p.DiscoveryMeta, EntityRef{VenueID: p.Event.VenueID, Kind: DiscoveryKindEvent, EventID: p.Event.EventID})
+}
+
+// AddSeries adds a series discovery payload to its run
+func (a *Assembler) AddSeries(p SeriesDiscoveryPayloadV0) error {
+	return a.add(p.DiscoveryMeta, EntityRef{VenueID: p.Event.VenueID, Kind: DiscoveryKindSeries, EventID: p.Event.EventID})
+}
+
+func (a *Assembler) add(meta *DiscoveryMetaV0, ref EntityRef) error {
+	if meta == nil {
+		return ErrNoDiscoveryMeta
+	}
+	if err := validateDiscoveryMeta(*meta); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, done := a.finalized[meta.DiscoveryRunID]; done {
+		return fmt.Errorf("%w: %s", ErrRunFinalized, meta.DiscoveryRunID)
+	}
+	now := a.cfg.Now()
+	run, ok := a.runs[meta.DiscoveryRunID]
+	if !ok {
+		run = &runState{
+			id:        meta.DiscoveryRunID,
+			batches:   make(map[string]*batchState),
+			seen:      make(map[EntityRef]bool),
+			startedAt: now,
+		}
+		a.runs[run.id] = run
+	}
+	run.lastAt = now
+
+	batch, ok := run.batches[meta.BatchID]
+	if !ok {
+		batch = &batchState{total: meta.BatchTotalCount, received: make(map[int]bool)}
+		run.batches[meta.BatchID] = batch
+	}
+
+	issue := func(kind IssueKind, format string, args ...any) error {
+		i := BatchIssue{Kind: kind, RunID: run.id, BatchID: meta.BatchID, Sequence: meta.BatchSequence, Message: fmt.Sprintf(format, args...)}
+		run.issues = append(run.issues, i)
+		return &i
+	}
+	if meta.BatchTotalCount != batch.total {
+		return issue(IssueTotalMismatch, "batch_total_count %d, first item said %d", meta.BatchTotalCount, batch.total)
+	}
+	if meta.BatchSequence > batch.total {
+		return issue(IssueOverrun, "batch_sequence %d exceeds batch_total_count %d", meta.BatchSequence, batch.total)
+	}
+	if batch.received[meta.BatchSequence] {
+		return issue(IssueDuplicate, "batch_sequence %d already received", meta.BatchSequence)
+	}
+	batch.received[meta.BatchSequence] = true
+	run.seen[ref] = true
+	return nil
+}
+
+// CloseRun finalizes a run once its producer has sent every batch. The run is
+// complete when every batch arrived in full without overruns or total
+// mismatches; duplicates alone do not fail a run. Payloads for the run that
+// arrive later are rejected with ErrRunFinalized.
+func (a *Assembler) CloseRun(runID string) (RunResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	run, ok := a.runs[runID]
+	if !ok {
+		if _, done := a.finalized[runID]; done {
+			return RunResult{}, fmt.Errorf("%w: %s", ErrRunFinalized, runID)
+		}
+		return RunResult{}, fmt.Errorf("unknown discovery run: %s", runID)
+	}
+	return a.finalize(run, false), nil
+}
+
+// Sweep finalizes every run that has been idle longer than the configured
+// timeout and returns their results, which are always partial. It also
+// forgets finalized runs older than the retention.
+func (a *Assembler) Sweep() []RunResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.cfg.Now()
+	a.prune(now)
+	if a.cfg.Timeout <= 0 {
+		return nil
+	}
+	var ids []string
+	for id, run := range a.runs {
+		if now.Sub(run.lastAt) > a.cfg.Timeout {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	results := make([]RunResult, 0, len(ids))
+	for _, id := range ids {
+		results = append(results, a.finalize(a.runs[id], true))
+	}
+	return results
+}
+
+// Pending returns the IDs of runs that are not yet finalized, sorted
+func (a *Assembler) Pending() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ids := make([]string, 0, len(a.runs))
+	for id := range a.runs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// prune forgets finalized runs older than the retention
+func (a *Assembler) prune(now time.Time) {
+	for id, at := range a.finalized {
+		if now.Sub(at) > a.cfg.Retention {
+			delete(a.finalized, id)
+		}
+	}
+}
+
+func (a *Assembler) finalize(run *runState, timedOut bool) RunResult {
+	now := a.cfg.Now()
+	a.prune(now)
+	delete(a.runs, run.id)
+	a.finalized[run.id] = now
+
+	result := RunResult{RunID: run.id, Status: RunComplete, StartedAt: run.startedAt, EndedAt: now}
+	for _, i := range run.issues {
+		if i.Kind != IssueDuplicate {
+			result.Status = RunPartial
+		}
+	}
+
+	batchIDs := make([]string, 0, len(run.batches))
+	for id := range run.batches {
+		batchIDs = append(batchIDs, id)
+	}
+	sort.Strings(batchIDs)
+	for _, id := range batchIDs {
+		batch := run.batches[id]
+		br := BatchResult{BatchID: id, Total: batch.total, Received: len(batch.received)}
+		for seq := 1; seq <= batch.total; seq++ {
+			if !batch.received[seq] {
+				br.Missing = append(br.Missing, seq)
+				run.issues = append(run.issues, BatchIssue{Kind: IssueMissing, RunID: run.id, BatchID: id, Sequence: seq,
+					Message: fmt.Sprintf("batch_sequence %d of %d not received", seq, batch.total)})
+				result.Status = RunPartial
+			}
+		}
+		result.Batches = append(result.Batches, br)
+	}
+	if timedOut {
+		run.issues = append(run.issues, BatchIssue{Kind: IssueTimeout, RunID: run.id,
+			Message: fmt.Sprintf("no payload for %s", a.cfg.Timeout)})
+		result.Status = RunPartial
+	}
+	result.Issues = run.issues
+
+	for ref := range run.seen {
+		result.Seen = append(result.Seen, ref)
+	}
+	sort.Slice(result.Seen, func(i, j int) bool { return refLess(result.Seen[i], result.Seen[j]) })
+	return result
+}
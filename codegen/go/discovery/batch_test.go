@@ -0,0 +1,143 @@
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func batchEvent(run, batch string, seq, total int, eventID string) EventDiscoveryPayloadV0 {
+	return EventDiscoveryPayloadV0{
+		Event:         EventMetadataV0{Kind: DiscoveryKindEvent, VenueID: VenueIDKalshi, EventID: eventID},
+		EventID:       "evt_" + eventID,
+		EventType:     EventTypeDiscovered,
+		VenueID:       VenueIDKalshi,
+		DiscoveryMeta: &DiscoveryMetaV0{BatchID: batch, BatchSequence: seq, BatchTotalCount: total, DiscoveryRunID: run},
+	}
+}
+
+func TestAssembler_CompleteRun(t *testing.T) {
+	a := NewAssembler(AssemblerConfig{})
+	for _, p := range []EventDiscoveryPayloadV0{
+		batchEvent("run1", "b2", 1, 1, "C"),
+		batchEvent("run1", "b1", 2, 2, "B"),
+		batchEvent("run1", "b1", 1, 2, "A"),
+	} {
+		if err := a.AddEvent(p); err != nil {
+			t.Fatalf("AddEvent() error = %v", err)
+		}
+	}
+	series := SeriesDiscoveryPayloadV0{
+		Event:         SeriesMetadataV0{Kind: DiscoveryKindSeries, VenueID: VenueIDKalshi, EventID: "KXPRES"},
+		DiscoveryMeta: &DiscoveryMetaV0{BatchID: "b3", BatchSequence: 1, BatchTotalCount: 1, DiscoveryRunID: "run1"},
+	}
+	if err := a.AddSeries(series); err != nil {
+		t.Fatal(err)
+	}
+
+	var issue *BatchIssue
+	if err := a.AddEvent(batchEvent("run1", "b1", 1, 2, "A")); !errors.As(err, &issue) || issue.Kind != IssueDuplicate {
+		t.Errorf("AddEvent(duplicate) error = %v, want duplicate issue", err)
+	}
+
+	result, err := a.CloseRun("run1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.SafeToExpire() || len(result.Batches) != 3 || len(result.Seen) != 4 {
+		t.Errorf("CloseRun() = %+v, want complete run with 3 batches and 4 entities", result)
+	}
+	if result.Seen[0] != (EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "A"}) {
+		t.Errorf("Seen[0] = %+v", result.Seen[0])
+	}
+
+	if err := a.AddEvent(batchEvent("run1", "b4", 1, 1, "D")); !errors.Is(err, ErrRunFinalized) {
+		t.Errorf("AddEvent(after close) error = %v, want ErrRunFinalized", err)
+	}
+	if len(a.Pending()) != 0 {
+		t.Errorf("Pending() = %v", a.Pending())
+	}
+}
+
+func TestAssembler_PartialRun(t *testing.T) {
+	a := NewAssembler(AssemblerConfig{})
+	a.AddEvent(batchEvent("run2", "b1", 1, 3, "A"))
+
+	var issue *BatchIssue
+	if err := a.AddEvent(batchEvent("run2", "b1", 4, 3, "Z")); !errors.As(err, &issue) || issue.Kind != IssueOverrun {
+		t.Errorf("AddEvent(overrun) error = %v", err)
+	}
+	if err := a.AddEvent(batchEvent("run2", "b1", 2, 5, "B")); !errors.As(err, &issue) || issue.Kind != IssueTotalMismatch {
+		t.Errorf("AddEvent(total mismatch) error = %v", err)
+	}
+	if err := a.AddEvent(EventDiscoveryPayloadV0{}); !errors.Is(err, ErrNoDiscoveryMeta) {
+		t.Errorf("AddEvent(no meta) error = %v", err)
+	}
+
+	result, err := a.CloseRun("run2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != RunPartial || result.SafeToExpire() {
+		t.Errorf("Status = %s, want partial", result.Status)
+	}
+	if b := result.Batches[0]; b.Received != 1 || len(b.Missing) != 2 || b.Missing[0] != 2 {
+		t.Errorf("Batches[0] = %+v", b)
+	}
+	kinds := map[IssueKind]int{}
+	for _, i := range result.Issues {
+		kinds[i.Kind]++
+	}
+	if kinds[IssueOverrun] != 1 || kinds[IssueTotalMismatch] != 1 || kinds[IssueMissing] != 2 {
+		t.Errorf("issues = %v", result.Issues)
+	}
+
+	if _, err := a.CloseRun("unknown"); err == nil {
+		t.Error("CloseRun(unknown) expected error")
+	}
+}
+
+func TestAssembler_Timeout(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	a := NewAssembler(AssemblerConfig{Timeout: time.Minute, Now: func() time.Time { return now }})
+	a.AddEvent(batchEvent("old", "b1", 1, 1, "A"))
+	now = now.Add(45 * time.Second)
+	a.AddEvent(batchEvent("new", "b1", 1, 2, "B"))
+
+	now = now.Add(30 * time.Second)
+	results := a.Sweep()
+	if len(results) != 1 || results[0].RunID != "old" || results[0].Status != RunPartial {
+		t.Fatalf("Sweep() = %+v, want only the idle run, partial", results)
+	}
+	last := results[0].Issues[len(results[0].Issues)-1]
+	if last.Kind != IssueTimeout {
+		t.Errorf("last issue = %+v, want timeout", last)
+	}
+	if pending := a.Pending(); len(pending) != 1 || pending[0] != "new" {
+		t.Errorf("Pending() = %v", pending)
+	}
+}
+
+func TestAssembler_Retention(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	a := NewAssembler(AssemblerConfig{Retention: time.Hour, Now: func() time.Time { return now }})
+	a.AddEvent(batchEvent("r1", "b1", 1, 1, "A"))
+	if _, err := a.CloseRun("r1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AddEvent(batchEvent("r1", "b1", 1, 1, "A")); !errors.Is(err, ErrRunFinalized) {
+		t.Errorf("AddEvent() within retention error = %v, want ErrRunFinalized", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	a.Sweep()
+	a.mu.Lock()
+	remembered := len(a.finalized)
+	a.mu.Unlock()
+	if remembered != 0 {
+		t.Errorf("finalized runs remembered after retention = %d, want 0", remembered)
+	}
+	if err := a.AddEvent(batchEvent("r1", "b1", 1, 1, "A")); err != nil {
+		t.Errorf("AddEvent() after retention error = %v, want a new run", err)
+	}
+}
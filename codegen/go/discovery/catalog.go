@@ -0,0 +1,320 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CatalogEntry is the state of one event or series at some point in time.
+// Exactly one of Event and Series is set, matching Ref.Kind.
+type CatalogEntry struct {
+	Ref       EntityRef         `json:"ref"`
+	Event     *EventMetadataV0  `json:"event,omitempty"`
+	Series    *SeriesMetadataV0 `json:"series,omitempty"`
+	Expired   bool              `json:"expired"`
+	UpdatedAt time.Time         `json:"updated_at"` // timestamp of the payload that produced this state
+}
+
+// LastSeen returns the metadata's last_seen time
+func (e CatalogEntry) LastSeen() time.Time {
+	if e.Event != nil {
+		return e.Event.LastSeen
+	}
+	if e.Series != nil {
+		return e.Series.LastSeen
+	}
+	return time.Time{}
+}
+
+// Catalog keeps the current and historical metadata of every discovered event
+// and series, keyed by venue, kind and event ID. It applies discovery payloads
+// in timestamp order, so late messages update history without overwriting
+// newer state. It is safe for concurrent use.
+type Catalog struct {
+	mu      sync.RWMutex
+	entries map[EntityRef][]CatalogEntry // versions sorted by UpdatedAt
+}
+
+// NewCatalog creates an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{entries: make(map[EntityRef][]CatalogEntry)}
+}
+
+// ApplyEvent applies an event discovery payload. Payloads that fail field
+// validation are rejected with a *ValidationReport.
+func (c *Catalog) ApplyEvent(p EventDiscoveryPayloadV0) error {
+	if err := CheckEventDiscoveryPayload(p).Err(); err != nil {
+		return err
+	}
+	event := p.Event
+	ref := EntityRef{VenueID: event.VenueID, Kind: DiscoveryKindEvent, EventID: event.EventID}
+	c.apply(CatalogEntry{Ref: ref, Event: &event, Expired: p.EventType == EventTypeExpired, UpdatedAt: p.Timestamp})
+	return nil
+}
+
+// ApplySeries applies a series discovery payload. Payloads that fail field
+// validation are rejected with a *ValidationReport.
+func (c *Catalog) ApplySeries(p SeriesDiscoveryPayloadV0) error {
+	if err := CheckSeriesDiscoveryPayload(p).Err(); err != nil {
+		return err
+	}
+	series := p.Event
+	ref := EntityRef{VenueID: series.VenueID, Kind: DiscoveryKindSeries, EventID: series.EventID}
+	c.apply(CatalogEntry{Ref: ref, Series: &series, Expired: p.EventType == EventTypeExpired, UpdatedAt: p.Timestamp})
+	return nil
+}
+
+// apply inserts a version. Every payload type replaces the metadata with the
+// payload's: discovered and updated mark the entity live, reviving it if it
+// was expired, and expired marks it expired. last_seen never moves backwards.
+func (c *Catalog) apply(entry CatalogEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.insert(entry)
+}
+
+// insert is apply for callers that hold the write lock
+func (c *Catalog) insert(entry CatalogEntry) {
+	versions := c.entries[entry.Ref]
+	i := sort.Search(len(versions), func(i int) bool { return versions[i].UpdatedAt.After(entry.UpdatedAt) })
+	if i > 0 {
+		prev := versions[i-1]
+		if prev.LastSeen().After(entry.LastSeen()) {
+			setLastSeen(&entry, prev.LastSeen())
+		}
+	}
+	versions = append(versions, CatalogEntry{})
+	copy(versions[i+1:], versions[i:])
+	versions[i] = entry
+	for j := i + 1; j < len(versions); j++ {
+		if entry.LastSeen().After(versions[j].LastSeen()) {
+			setLastSeen(&versions[j], entry.LastSeen())
+		}
+	}
+	c.entries[entry.Ref] = versions
+}
+
+func setLastSeen(entry *CatalogEntry, t time.Time) {
+	if entry.Event != nil {
+		event := *entry.Event
+		event.LastSeen = t
+		entry.Event = &event
+	}
+	if entry.Series != nil {
+		series := *entry.Series
+		series.LastSeen = t
+		entry.Series = &series
+	}
+}
+
+// Get returns the latest state of an event or series, expired or not
+func (c *Catalog) Get(ref EntityRef) (CatalogEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	versions := c.entries[ref]
+	if len(versions) == 0 {
+		return CatalogEntry{}, false
+	}
+	return versions[len(versions)-1], true
+}
+
+// GetAsOf returns the state of an event or series as of t, i.e. the last
+// payload with a timestamp at or before t
+func (c *Catalog) GetAsOf(ref EntityRef, t time.Time) (CatalogEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return asOf(c.entries[ref], t)
+}
+
+func asOf(versions []CatalogEntry, t time.Time) (CatalogEntry, bool) {
+	i := sort.Search(len(versions), func(i int) bool { return versions[i].UpdatedAt.After(t) })
+	if i == 0 {
+		return CatalogEntry{}, false
+	}
+	return versions[i-1], true
+}
+
+// Live returns the entities of a venue that are not expired, sorted by kind
+// and event ID. An empty venue returns every venue's live entities.
+func (c *Catalog) Live(venue VenueID) []CatalogEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var out []CatalogEntry
+	for ref, versions := range c.entries {
+		if latest := versions[len(versions)-1]; (venue == "" || ref.VenueID == venue) && !latest.Expired {
+			out = append(out, latest)
+		}
+	}
+	sortEntries(out)
+	return out
+}
+
+// LiveAsOf returns the entities that were live at t, across all venues
+func (c *Catalog) LiveAsOf(t time.Time) []CatalogEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var out []CatalogEntry
+	for _, versions := range c.entries {
+		if entry, ok := asOf(versions, t); ok && !entry.Expired {
+			out = append(out, entry)
+		}
+	}
+	sortEntries(out)
+	return out
+}
+
+// Len returns the number of entities, expired ones included
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// ExpireMissing marks as expired, at time at, every live entity the run did
+// not see, among the (venue, kind) pairs the run covered: a run that listed
+// only kalshi events expires no kalshi series. Entities updated after the run
+// started are left alone, since the run could not have seen them. The latest
+// metadata is kept; when at is before the latest version, the entity expires
+// at that version's time instead. It refuses runs that are not SafeToExpire
+// and returns the entities it expired, sorted. The catalog is updated
+// atomically.
+func (c *Catalog) ExpireMissing(result RunResult, at time.Time) ([]EntityRef, error) {
+	if !result.SafeToExpire() {
+		return nil, fmt.Errorf("discovery run %s is %s, not safe to expire", result.RunID, result.Status)
+	}
+	type scope struct {
+		venue VenueID
+		kind  DiscoveryKind
+	}
+	seen := make(map[EntityRef]bool, len(result.Seen))
+	covered := make(map[scope]bool)
+	for _, ref := range result.Seen {
+		seen[ref] = true
+		covered[scope{ref.VenueID, ref.Kind}] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var refs []EntityRef
+	for ref, versions := range c.entries {
+		latest := versions[len(versions)-1]
+		if latest.Expired || seen[ref] || !covered[scope{ref.VenueID, ref.Kind}] {
+			continue
+		}
+		if latest.UpdatedAt.After(result.StartedAt) {
+			continue
+		}
+		latest.Expired = true
+		if at.After(latest.UpdatedAt) {
+			latest.UpdatedAt = at
+		}
+		c.insert(latest)
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refLess(refs[i], refs[j]) })
+	return refs, nil
+}
+
+// Compact drops history older than before, keeping the version in effect at
+// before so that as-of queries from then on give the same answers
+func (c *Catalog) Compact(before time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for ref, versions := range c.entries {
+		i := sort.Search(len(versions), func(i int) bool { return versions[i].UpdatedAt.After(before) })
+		if i > 1 {
+			c.entries[ref] = append([]CatalogEntry(nil), versions[i-1:]...)
+		}
+	}
+}
+
+// catalogSnapshot is the file format written by Save
+type catalogSnapshot struct {
+	Version  int              `json:"version"`
+	SavedAt  time.Time        `json:"saved_at"`
+	Versions [][]CatalogEntry `json:"entities"`
+}
+
+const catalogSnapshotVersion = 1
+
+// Save writes the catalog, history included, to path. The file is written
+// to a temporary file in the same directory and renamed into place, so a
+// crash never leaves a truncated snapshot.
+func (c *Catalog) Save(path string) error {
+	c.mu.RLock()
+	snapshot := catalogSnapshot{Version: catalogSnapshotVersion, SavedAt: time.Now().UTC()}
+	for _, versions := range c.entries {
+		snapshot.Versions = append(snapshot.Versions, append([]CatalogEntry(nil), versions...))
+	}
+	c.mu.RUnlock()
+	sort.Slice(snapshot.Versions, func(i, j int) bool {
+		return refLess(snapshot.Versions[i][0].Ref, snapshot.Versions[j][0].Ref)
+	})
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+	return nil
+}
+
+// LoadCatalog restores a catalog written by Save
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	var snapshot catalogSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode catalog %s: %w", path, err)
+	}
+	if snapshot.Version != catalogSnapshotVersion {
+		return nil, fmt.Errorf("unsupported catalog snapshot version %d", snapshot.Version)
+	}
+
+	c := NewCatalog()
+	for _, versions := range snapshot.Versions {
+		for _, entry := range versions {
+			if (entry.Event == nil) == (entry.Series == nil) {
+				return nil, fmt.Errorf("catalog entry %s/%s/%s must hold exactly one of event or series",
+					entry.Ref.VenueID, entry.Ref.Kind, entry.Ref.EventID)
+			}
+			c.apply(entry)
+		}
+	}
+	return c, nil
+}
+
+func sortEntries(entries []CatalogEntry) {
+	sort.Slice(entries, func(i, j int) bool { return refLess(entries[i].Ref, entries[j].Ref) })
+}
+
+func refLess(x, y EntityRef) bool {
+	if x.VenueID != y.VenueID {
+		return x.VenueID < y.VenueID
+	}
+	if x.Kind != y.Kind {
+		return x.Kind < y.Kind
+	}
+	return x.EventID < y.EventID
+}
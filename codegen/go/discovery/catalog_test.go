@@ -0,0 +1,230 @@
+package discovery
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var catalogT0 = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func catalogEvent(eventID string, eventType EventType, at time.Time, title string) EventDiscoveryPayloadV0 {
+	return EventDiscoveryPayloadV0{
+		Event: EventMetadataV0{
+			Kind:         DiscoveryKindEvent,
+			VenueID:      VenueIDKalshi,
+			EventID:      eventID,
+			Title:        title,
+			Active:       boolPtr(eventType != EventTypeExpired),
+			Closed:       boolPtr(eventType == EventTypeExpired),
+			DiscoveredAt: catalogT0,
+			LastSeen:     at,
+		},
+		EventID:   "evt_" + eventID,
+		EventType: eventType,
+		Timestamp: at,
+		VenueID:   VenueIDKalshi,
+	}
+}
+
+func TestCatalog_Lifecycle(t *testing.T) {
+	c := NewCatalog()
+	ref := EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "PRES-28"}
+
+	for _, p := range []EventDiscoveryPayloadV0{
+		catalogEvent("PRES-28", EventTypeDiscovered, catalogT0, "v1"),
+		catalogEvent("PRES-28", EventTypeUpdated, catalogT0.Add(2*time.Hour), "v2"),
+		catalogEvent("PRES-28", EventTypeExpired, catalogT0.Add(4*time.Hour), "v3"),
+		// Late update: lands in history but does not revive the event
+		catalogEvent("PRES-28", EventTypeUpdated, catalogT0.Add(3*time.Hour), "late"),
+		catalogEvent("FED-25", EventTypeDiscovered, catalogT0.Add(time.Hour), "fed"),
+	} {
+		if err := c.ApplyEvent(p); err != nil {
+			t.Fatalf("ApplyEvent() error = %v", err)
+		}
+	}
+
+	latest, ok := c.Get(ref)
+	if !ok || !latest.Expired || latest.Event.Title != "v3" {
+		t.Errorf("Get() = %+v, want expired v3", latest)
+	}
+	if got, _ := c.GetAsOf(ref, catalogT0.Add(3*time.Hour+time.Minute)); got.Event.Title != "late" || got.Expired {
+		t.Errorf("GetAsOf(3h) = %+v, want late update", got)
+	}
+	if _, ok := c.GetAsOf(ref, catalogT0.Add(-time.Minute)); ok {
+		t.Error("GetAsOf(before discovery) found an entry")
+	}
+
+	titles := func(entries []CatalogEntry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Event.Title)
+		}
+		return out
+	}
+	if got := titles(c.Live(VenueIDKalshi)); !reflect.DeepEqual(got, []string{"fed"}) {
+		t.Errorf("Live() = %v", got)
+	}
+	if got := titles(c.LiveAsOf(catalogT0.Add(90 * time.Minute))); !reflect.DeepEqual(got, []string{"fed", "v1"}) {
+		t.Errorf("LiveAsOf(90m) = %v", got)
+	}
+
+	// Reviving an expired event with a newer discovery
+	if err := c.ApplyEvent(catalogEvent("PRES-28", EventTypeDiscovered, catalogT0.Add(5*time.Hour), "v4")); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Live("")) != 2 {
+		t.Errorf("Live() after rediscovery = %v", titles(c.Live("")))
+	}
+
+	if err := c.ApplyEvent(EventDiscoveryPayloadV0{EventType: EventTypeUpdated}); err == nil {
+		t.Error("ApplyEvent(invalid) expected error")
+	}
+}
+
+func TestCatalog_LastSeenNeverMovesBack(t *testing.T) {
+	c := NewCatalog()
+	ref := EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "PRES-28"}
+	newer := catalogEvent("PRES-28", EventTypeUpdated, catalogT0.Add(2*time.Hour), "v2")
+	older := catalogEvent("PRES-28", EventTypeUpdated, catalogT0.Add(time.Hour), "v1")
+	older.Event.LastSeen = catalogT0.Add(3 * time.Hour)
+	c.ApplyEvent(newer)
+	c.ApplyEvent(older)
+
+	latest, _ := c.Get(ref)
+	if !latest.LastSeen().Equal(catalogT0.Add(3*time.Hour)) || latest.Event.Title != "v2" {
+		t.Errorf("Get() = %s last seen %s", latest.Event.Title, latest.LastSeen())
+	}
+}
+
+func TestCatalog_ExpireMissing(t *testing.T) {
+	c := NewCatalog()
+	c.ApplyEvent(catalogEvent("A", EventTypeDiscovered, catalogT0, "a"))
+	c.ApplyEvent(catalogEvent("B", EventTypeDiscovered, catalogT0, "b"))
+
+	run := RunResult{RunID: "r", Status: RunPartial, Seen: []EntityRef{{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "A"}}, StartedAt: catalogT0}
+	if _, err := c.ExpireMissing(run, catalogT0.Add(time.Hour)); err == nil {
+		t.Error("ExpireMissing(partial run) expected error")
+	}
+
+	run.Status = RunComplete
+	expired, err := c.ExpireMissing(run, catalogT0.Add(time.Hour))
+	if err != nil || len(expired) != 1 || expired[0].EventID != "B" {
+		t.Fatalf("ExpireMissing() = %v, %v", expired, err)
+	}
+	if live := c.Live(""); len(live) != 1 || live[0].Ref.EventID != "A" {
+		t.Errorf("Live() = %v", live)
+	}
+}
+
+func TestCatalog_ExpireMissing_ScopedByKind(t *testing.T) {
+	c := NewCatalog()
+	c.ApplyEvent(catalogEvent("A", EventTypeDiscovered, catalogT0, "a"))
+	c.ApplyEvent(catalogEvent("B", EventTypeDiscovered, catalogT0, "b"))
+	if err := c.ApplySeries(SeriesDiscoveryPayloadV0{
+		Event: SeriesMetadataV0{
+			Kind: DiscoveryKindSeries, VenueID: VenueIDKalshi, EventID: "KXPRES", Title: "Presidency",
+			Active: boolPtr(true), Closed: boolPtr(false), DiscoveredAt: catalogT0, LastSeen: catalogT0,
+		},
+		EventID: "evt_series", EventType: EventTypeDiscovered, Timestamp: catalogT0, VenueID: VenueIDKalshi,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A complete run that listed only kalshi events says nothing about series
+	run := RunResult{RunID: "r", Status: RunComplete, Seen: []EntityRef{{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "A"}}, StartedAt: catalogT0}
+	expired, err := c.ExpireMissing(run, catalogT0.Add(time.Hour))
+	want := []EntityRef{{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "B"}}
+	if err != nil || !reflect.DeepEqual(expired, want) {
+		t.Fatalf("ExpireMissing() = %v, %v, want %v", expired, err, want)
+	}
+	series := EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindSeries, EventID: "KXPRES"}
+	if entry, ok := c.Get(series); !ok || entry.Expired {
+		t.Errorf("series expired by an events-only run: %+v", entry)
+	}
+	if entry, _ := c.Get(want[0]); entry.Event == nil || entry.Event.Title != "b" {
+		t.Errorf("expired entry lost its metadata: %+v", entry)
+	}
+
+	// A run covering series expires the series it did not list
+	run.Seen = []EntityRef{{VenueID: VenueIDKalshi, Kind: DiscoveryKindSeries, EventID: "OTHER"}}
+	if expired, err := c.ExpireMissing(run, catalogT0.Add(2*time.Hour)); err != nil || !reflect.DeepEqual(expired, []EntityRef{series}) {
+		t.Errorf("ExpireMissing(series run) = %v, %v", expired, err)
+	}
+}
+
+func TestCatalog_ExpireMissing_Timing(t *testing.T) {
+	c := NewCatalog()
+	c.ApplyEvent(catalogEvent("A", EventTypeDiscovered, catalogT0, "a"))
+	c.ApplyEvent(catalogEvent("B", EventTypeDiscovered, catalogT0, "b"))
+	c.ApplyEvent(catalogEvent("B", EventTypeUpdated, catalogT0.Add(2*time.Hour), "b2"))
+	c.ApplyEvent(catalogEvent("C", EventTypeDiscovered, catalogT0.Add(4*time.Hour), "c"))
+
+	// The run started before C was discovered, so it could not have seen it.
+	// B was updated after at but before the run started.
+	run := RunResult{RunID: "r", Status: RunComplete, Seen: []EntityRef{{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "A"}}, StartedAt: catalogT0.Add(3 * time.Hour)}
+	expired, err := c.ExpireMissing(run, catalogT0.Add(time.Hour))
+	b := EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "B"}
+	if err != nil || !reflect.DeepEqual(expired, []EntityRef{b}) {
+		t.Fatalf("ExpireMissing() = %v, %v, want [%v]", expired, err, b)
+	}
+	if live := c.Live(""); len(live) != 2 || live[0].Ref.EventID != "A" || live[1].Ref.EventID != "C" {
+		t.Errorf("Live() = %v, want A and C", live)
+	}
+	if entry, ok := c.Get(b); !ok || !entry.Expired || !entry.UpdatedAt.Equal(catalogT0.Add(2*time.Hour)) || entry.Event.Title != "b2" {
+		t.Errorf("Get(B) = %+v, want b2 expired at its last update", entry)
+	}
+}
+
+func TestCatalog_SaveAndLoad(t *testing.T) {
+	c := NewCatalog()
+	c.ApplyEvent(catalogEvent("PRES-28", EventTypeDiscovered, catalogT0, "v1"))
+	c.ApplyEvent(catalogEvent("PRES-28", EventTypeUpdated, catalogT0.Add(time.Hour), "v2"))
+	c.ApplySeries(SeriesDiscoveryPayloadV0{
+		Event: SeriesMetadataV0{
+			Kind: DiscoveryKindSeries, VenueID: VenueIDPolymarket, EventID: "10244", Title: "Fed",
+			Active: boolPtr(true), Closed: boolPtr(false), DiscoveredAt: catalogT0, LastSeen: catalogT0,
+		},
+		EventID: "evt_series", EventType: EventTypeDiscovered, Timestamp: catalogT0, VenueID: VenueIDPolymarket,
+	})
+
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	restored, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+	if restored.Len() != 2 || !reflect.DeepEqual(restored.Live(""), c.Live("")) {
+		t.Errorf("restored Live() = %+v, want %+v", restored.Live(""), c.Live(""))
+	}
+	ref := EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "PRES-28"}
+	if got, _ := restored.GetAsOf(ref, catalogT0.Add(time.Minute)); got.Event.Title != "v1" {
+		t.Errorf("restored history lost: %+v", got)
+	}
+
+	bad, _ := json.Marshal(catalogSnapshot{Version: 99})
+	os.WriteFile(path, bad, 0o644)
+	if _, err := LoadCatalog(path); err == nil {
+		t.Error("LoadCatalog(unsupported version) expected error")
+	}
+}
+
+func TestCatalog_Compact(t *testing.T) {
+	c := NewCatalog()
+	ref := EntityRef{VenueID: VenueIDKalshi, Kind: DiscoveryKindEvent, EventID: "PRES-28"}
+	for i, title := range []string{"v1", "v2", "v3"} {
+		c.ApplyEvent(catalogEvent("PRES-28", EventTypeUpdated, catalogT0.Add(time.Duration(i)*time.Hour), title))
+	}
+	c.Compact(catalogT0.Add(90 * time.Minute))
+	if got, _ := c.GetAsOf(ref, catalogT0.Add(90*time.Minute)); got.Event.Title != "v2" {
+		t.Errorf("GetAsOf after Compact = %+v", got)
+	}
+	if _, ok := c.GetAsOf(ref, catalogT0.Add(30*time.Minute)); ok {
+		t.Error("Compact kept history before the cutoff")
+	}
+}
@@ -0,0 +1,407 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Category is the unified market taxonomy. Its values match the API's
+// MarketCategory, so a resolved category can serve GetMarkets(category=...).
+type Category string
+
+const (
+	CategoryPolitics  Category = "politics"
+	CategoryCrypto    Category = "crypto"
+	CategoryEconomics Category = "economics"
+	CategorySports    Category = "sports"
+	CategoryWeather   Category = "weather"
+)
+
+// Categories returns every unified category
+func Categories() []Category {
+	return []Category{CategoryPolitics, CategoryCrypto, CategoryEconomics, CategorySports, CategoryWeather}
+}
+
+// IsValid reports whether c is a unified category
+func (c Category) IsValid() bool {
+	for _, known := range Categories() {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+// VenueCategoryV0 is one venue-native category or tag from a raw.categories.v0
+// payload. Fields the venue does not send are left empty; unmapped native
+// fields are kept in ExtraMetadata.
+type VenueCategoryV0 struct {
+	VenueID       VenueID        `json:"venue_id"`
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Slug          *string        `json:"slug,omitempty"`
+	Description   *string        `json:"description,omitempty"`
+	ParentID      *string        `json:"parent_id,omitempty"`
+	Subcategories []string       `json:"subcategories,omitempty"`
+	EventCount    *int           `json:"event_count,omitempty"`
+	MarketCount   *int           `json:"market_count,omitempty"`
+	VolumeUSD     *float64       `json:"volume_usd,omitempty"`
+	ExtraMetadata map[string]any `json:"extra_metadata,omitempty"`
+}
+
+// ParseVenueCategory reads a raw.categories.v0 payload. Polymarket payloads
+// are Gamma API tags (id, name or label, slug, parent_category); Kalshi
+// payloads are categories aggregated from events, identified by name.
+func ParseVenueCategory(venue VenueID, payload []byte) (VenueCategoryV0, error) {
+	o, err := decodeNative(venue, "category", payload)
+	if err != nil {
+		return VenueCategoryV0{}, err
+	}
+	c := VenueCategoryV0{VenueID: venue}
+	switch venue {
+	case VenueIDPolymarket:
+		c.ID = o.id("id")
+		c.Name = o.str("name", "label")
+		c.Slug = o.optStr("slug")
+		c.Description = o.optStr("description")
+		c.ParentID = o.optStr("parent_category", "parentCategory")
+		c.EventCount = o.count("event_count")
+		c.MarketCount = o.count("market_count")
+		c.VolumeUSD = o.usd("volume")
+	case VenueIDKalshi:
+		c.Name = o.str("category", "name")
+		c.ID = c.Name
+		c.Description = o.optStr("description")
+		c.ParentID = o.optStr("parent_category")
+		c.EventCount = o.count("event_count")
+		c.MarketCount = o.count("market_count")
+		c.VolumeUSD = o.usd("total_volume", "volume")
+	default:
+		return VenueCategoryV0{}, &AdapterError{Venue: venue, Kind: "category", Message: "unsupported venue"}
+	}
+	c.Subcategories = o.strs("subcategories")
+	if o.err != nil {
+		return VenueCategoryV0{}, o.err
+	}
+	c.ExtraMetadata, _ = o.extra()
+	if c.ID == "" || c.Name == "" {
+		return c, &AdapterError{Venue: venue, Kind: "category", Message: "id and name are required"}
+	}
+	return c, nil
+}
+
+// CategoryMapping maps venue-native category names and tags onto the unified
+// taxonomy. Names are matched case-insensitively, with '-' and '_' treated as
+// spaces. Venue-specific entries take precedence over entries for every
+// venue. CategoryMapping values are immutable.
+type CategoryMapping struct {
+	categories map[mappingKey]Category
+	tags       map[mappingKey]Category
+}
+
+type mappingKey struct {
+	venue VenueID // empty for every venue
+	name  string  // normalized
+}
+
+// DefaultCategoryMapping maps the category names and common tags both venues
+// use today, e.g. Kalshi's "Climate and Weather" and "Financials"
+func DefaultCategoryMapping() CategoryMapping {
+	m := CategoryMapping{}
+	defaults := map[Category][]string{
+		CategoryPolitics:  {"politics", "elections", "election", "us politics", "world politics", "geopolitics"},
+		CategoryCrypto:    {"crypto", "cryptocurrency", "bitcoin", "ethereum", "solana"},
+		CategoryEconomics: {"economics", "economy", "financials", "finance", "fed", "inflation", "interest rates"},
+		CategorySports:    {"sports", "nfl", "nba", "mlb", "nhl", "soccer", "football", "tennis", "golf"},
+		CategoryWeather:   {"weather", "climate", "climate and weather", "hurricanes"},
+	}
+	for category, names := range defaults {
+		m = m.WithCategory("", category, names...).WithTag("", category, names...)
+	}
+	return m
+}
+
+// WithCategory returns a copy of m that maps the venue's native category
+// names to c. An empty venue applies to every venue.
+func (m CategoryMapping) WithCategory(venue VenueID, c Category, names ...string) CategoryMapping {
+	m.categories = withNames(m.categories, venue, c, names)
+	return m
+}
+
+// WithTag returns a copy of m that maps the venue's tags to c. An empty venue
+// applies to every venue.
+func (m CategoryMapping) WithTag(venue VenueID, c Category, tags ...string) CategoryMapping {
+	m.tags = withNames(m.tags, venue, c, tags)
+	return m
+}
+
+func withNames(src map[mappingKey]Category, venue VenueID, c Category, names []string) map[mappingKey]Category {
+	out := make(map[mappingKey]Category, len(src)+len(names))
+	for k, v := range src {
+		out[k] = v
+	}
+	for _, name := range names {
+		out[mappingKey{venue, normalizeCategoryName(name)}] = c
+	}
+	return out
+}
+
+// Category returns the unified category of a venue-native category name
+func (m CategoryMapping) Category(venue VenueID, name string) (Category, bool) {
+	return lookupName(m.categories, venue, name)
+}
+
+// Tag returns the unified category of a venue tag
+func (m CategoryMapping) Tag(venue VenueID, tag string) (Category, bool) {
+	return lookupName(m.tags, venue, tag)
+}
+
+func lookupName(names map[mappingKey]Category, venue VenueID, name string) (Category, bool) {
+	name = normalizeCategoryName(name)
+	if c, ok := names[mappingKey{venue, name}]; ok {
+		return c, true
+	}
+	c, ok := names[mappingKey{"", name}]
+	return c, ok
+}
+
+func normalizeCategoryName(name string) string {
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(name))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// CategorySource says which metadata field a category was resolved from
+type CategorySource string
+
+const (
+	SourceCategory     CategorySource = "category"      // the entity's own category
+	SourceTag          CategorySource = "tag"           // one of the entity's tags
+	SourceParentSeries CategorySource = "parent_series" // inherited from the parent series
+)
+
+// CategoryMatch is the unified category resolved for an event or series.
+// Native is the venue name that matched; Via is the venue category it was
+// reached through when Native is a subcategory, otherwise empty.
+type CategoryMatch struct {
+	Category Category
+	Source   CategorySource
+	Native   string
+	Via      string
+}
+
+func (m CategoryMatch) String() string {
+	if m.Via != "" {
+		return fmt.Sprintf("%s (%s %q via %q)", m.Category, m.Source, m.Native, m.Via)
+	}
+	return fmt.Sprintf("%s (%s %q)", m.Category, m.Source, m.Native)
+}
+
+// CategoryResolver resolves discovered events and series to the unified
+// taxonomy. It tries the entity's category, then its tags in order, then the
+// category of its parent series. Venue categories learned from
+// raw.categories.v0 let subcategories resolve through their parent, e.g.
+// Kalshi's "Elections" under "Politics". It is safe for concurrent use.
+type CategoryResolver struct {
+	mapping CategoryMapping
+
+	mu      sync.RWMutex
+	parents map[mappingKey]string // normalized native name -> parent ID or name
+	names   map[mappingKey]string // native ID -> name
+}
+
+// NewCategoryResolver creates a resolver using mapping
+func NewCategoryResolver(mapping CategoryMapping) *CategoryResolver {
+	return &CategoryResolver{mapping: mapping, parents: make(map[mappingKey]string), names: make(map[mappingKey]string)}
+}
+
+// AddVenueCategory records a venue category's parent and subcategories. Both
+// the category's name and slug are recorded.
+func (r *CategoryResolver) AddVenueCategory(c VenueCategoryV0) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names[mappingKey{c.VenueID, c.ID}] = c.Name
+	names := []string{c.Name}
+	if c.Slug != nil {
+		names = append(names, *c.Slug)
+	}
+	for _, name := range names {
+		if c.ParentID != nil && *c.ParentID != c.ID {
+			r.parents[mappingKey{c.VenueID, normalizeCategoryName(name)}] = *c.ParentID
+		}
+	}
+	for _, sub := range c.Subcategories {
+		if normalizeCategoryName(sub) != normalizeCategoryName(c.Name) {
+			r.parents[mappingKey{c.VenueID, normalizeCategoryName(sub)}] = c.Name
+		}
+	}
+}
+
+// ResolveEvent resolves an event's unified category. parent is the event's
+// parent series, if known.
+func (r *CategoryResolver) ResolveEvent(e EventMetadataV0, parent *SeriesMetadataV0) (CategoryMatch, bool) {
+	if m, ok := r.resolve(e.VenueID, e.Category, e.Tags); ok {
+		return m, true
+	}
+	if parent != nil {
+		if m, ok := r.ResolveSeries(*parent); ok {
+			m.Source = SourceParentSeries
+			return m, true
+		}
+	}
+	return CategoryMatch{}, false
+}
+
+// ResolveSeries resolves a series' unified category
+func (r *CategoryResolver) ResolveSeries(s SeriesMetadataV0) (CategoryMatch, bool) {
+	return r.resolve(s.VenueID, s.Category, s.Tags)
+}
+
+func (r *CategoryResolver) resolve(venue VenueID, category *string, tags []string) (CategoryMatch, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if category != nil {
+		if c, via, ok := r.lookup(venue, *category, r.mapping.Category); ok {
+			return CategoryMatch{Category: c, Source: SourceCategory, Native: *category, Via: via}, true
+		}
+	}
+	for _, tag := range tags {
+		if c, via, ok := r.lookup(venue, tag, r.mapping.Tag); ok {
+			return CategoryMatch{Category: c, Source: SourceTag, Native: tag, Via: via}, true
+		}
+	}
+	return CategoryMatch{}, false
+}
+
+// lookup maps name directly, then walks up the learned venue parents, which
+// are matched as categories
+func (r *CategoryResolver) lookup(venue VenueID, name string, direct func(VenueID, string) (Category, bool)) (Category, string, bool) {
+	if c, ok := direct(venue, name); ok {
+		return c, "", true
+	}
+	seen := map[string]bool{normalizeCategoryName(name): true}
+	for {
+		parent, ok := r.parents[mappingKey{venue, normalizeCategoryName(name)}]
+		if n, known := r.names[mappingKey{venue, parent}]; known {
+			parent = n
+		}
+		if !ok || seen[normalizeCategoryName(parent)] {
+			return "", "", false
+		}
+		seen[normalizeCategoryName(parent)] = true
+		if c, ok := r.mapping.Category(venue, parent); ok {
+			return c, parent, true
+		}
+		name = parent
+	}
+}
+
+// Resolve resolves every live entity in the catalog. Events without a
+// category of their own inherit their parent series' category.
+func (r *CategoryResolver) Resolve(c *Catalog) map[EntityRef]CategoryMatch {
+	live := c.Live("")
+	series := make(map[EntityRef]*SeriesMetadataV0)
+	for _, entry := range live {
+		if entry.Series != nil {
+			series[entry.Ref] = entry.Series
+		}
+	}
+	out := make(map[EntityRef]CategoryMatch, len(live))
+	for _, entry := range live {
+		var m CategoryMatch
+		var ok bool
+		switch {
+		case entry.Event != nil:
+			var parent *SeriesMetadataV0
+			if id := entry.Event.ParentSeriesID; id != nil {
+				parent = series[EntityRef{VenueID: entry.Ref.VenueID, Kind: DiscoveryKindSeries, EventID: *id}]
+			}
+			m, ok = r.ResolveEvent(*entry.Event, parent)
+		case entry.Series != nil:
+			m, ok = r.ResolveSeries(*entry.Series)
+		}
+		if ok {
+			out[entry.Ref] = m
+		}
+	}
+	return out
+}
+
+// LiveInCategory returns the catalog's live events in a unified category,
+// sorted, e.g. to serve GetMarkets(category=...) from discovery data
+func (r *CategoryResolver) LiveInCategory(c *Catalog, category Category) []CatalogEntry {
+	resolved := r.Resolve(c)
+	var out []CatalogEntry
+	for _, entry := range c.Live("") {
+		if entry.Event != nil && resolved[entry.Ref].Category == category {
+			out = append(out, entry)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return refLess(out[i].Ref, out[j].Ref) })
+	return out
+}
+
+// MarshalJSON encodes the mapping as {"categories": {venue: {name: category}},
+// "tags": {...}}, using "*" for entries that apply to every venue
+func (m CategoryMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(categoryMappingJSON{Categories: encodeNames(m.categories), Tags: encodeNames(m.tags)})
+}
+
+// UnmarshalJSON decodes the MarshalJSON format, so mappings can be kept in
+// configuration. Unknown unified categories are rejected.
+func (m *CategoryMapping) UnmarshalJSON(data []byte) error {
+	var raw categoryMappingJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	out := CategoryMapping{}
+	for venue, names := range raw.Categories {
+		for name, c := range names {
+			if !c.IsValid() {
+				return fmt.Errorf("category mapping: %s %q: unknown category %q", venue, name, c)
+			}
+			out = out.WithCategory(decodeVenue(venue), c, name)
+		}
+	}
+	for venue, names := range raw.Tags {
+		for name, c := range names {
+			if !c.IsValid() {
+				return fmt.Errorf("category mapping: %s tag %q: unknown category %q", venue, name, c)
+			}
+			out = out.WithTag(decodeVenue(venue), c, name)
+		}
+	}
+	*m = out
+	return nil
+}
+
+type categoryMappingJSON struct {
+	Categories map[string]map[string]Category `json:"categories,omitempty"`
+	Tags       map[string]map[string]Category `json:"tags,omitempty"`
+}
+
+const anyVenue = "*"
+
+func encodeNames(names map[mappingKey]Category) map[string]map[string]Category {
+	out := make(map[string]map[string]Category)
+	for k, c := range names {
+		venue := string(k.venue)
+		if venue == "" {
+			venue = anyVenue
+		}
+		if out[venue] == nil {
+			out[venue] = make(map[string]Category)
+		}
+		out[venue][k.name] = c
+	}
+	return out
+}
+
+func decodeVenue(venue string) VenueID {
+	if venue == anyVenue {
+		return ""
+	}
+	return VenueID(venue)
+}
@@ -0,0 +1,157 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/api"
+)
+
+func TestCategories_MatchAPI(t *testing.T) {
+	apiCategories := []api.MarketCategory{
+		api.MarketCategoryPolitics, api.MarketCategoryCrypto, api.MarketCategoryEconomics,
+		api.MarketCategorySports, api.MarketCategoryWeather,
+	}
+	for i, c := range Categories() {
+		if string(c) != string(apiCategories[i]) {
+			t.Errorf("Categories()[%d] = %s, want %s", i, c, apiCategories[i])
+		}
+	}
+}
+
+func TestParseVenueCategory_Examples(t *testing.T) {
+	polymarket, err := ParseVenueCategory(VenueIDPolymarket, rawExamplePayload(t, "raw.categories.polymarket.example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polymarket.ID != "tag_politics" || polymarket.Name != "Politics" || *polymarket.MarketCount != 150 || *polymarket.VolumeUSD != 5000000 {
+		t.Errorf("polymarket = %+v", polymarket)
+	}
+	if polymarket.ParentID != nil || !reflect.DeepEqual(polymarket.ExtraMetadata, map[string]any{"color": "#ff6b35"}) {
+		t.Errorf("polymarket parent = %v, extra = %v", polymarket.ParentID, polymarket.ExtraMetadata)
+	}
+
+	kalshi, err := ParseVenueCategory(VenueIDKalshi, rawExamplePayload(t, "raw.categories.kalshi.example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kalshi.ID != "Politics" || *kalshi.EventCount != 75 || len(kalshi.Subcategories) != 3 {
+		t.Errorf("kalshi = %+v", kalshi)
+	}
+
+	var adapterErr *AdapterError
+	if _, err := ParseVenueCategory(VenueIDKalshi, []byte(`{"category": "Sports", "event_count": -1}`)); !errors.As(err, &adapterErr) {
+		t.Errorf("negative count error = %v", err)
+	}
+}
+
+func TestCategoryMapping(t *testing.T) {
+	m := DefaultCategoryMapping().
+		WithCategory(VenueIDKalshi, CategoryEconomics, "Companies").
+		WithTag(VenueIDPolymarket, CategoryCrypto, "memecoins")
+
+	tests := []struct {
+		venue   VenueID
+		name    string
+		tag     bool
+		want    Category
+		wantHit bool
+	}{
+		{VenueIDKalshi, "Climate and Weather", false, CategoryWeather, true},
+		{VenueIDPolymarket, "US-Politics", false, CategoryPolitics, true},
+		{VenueIDKalshi, "companies", false, CategoryEconomics, true},
+		{VenueIDPolymarket, "Companies", false, "", false},
+		{VenueIDPolymarket, "memecoins", true, CategoryCrypto, true},
+		{VenueIDKalshi, "memecoins", true, "", false},
+	}
+	for _, tt := range tests {
+		lookup := m.Category
+		if tt.tag {
+			lookup = m.Tag
+		}
+		if got, ok := lookup(tt.venue, tt.name); got != tt.want || ok != tt.wantHit {
+			t.Errorf("%s %q = %s, %v; want %s, %v", tt.venue, tt.name, got, ok, tt.want, tt.wantHit)
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded CategoryMapping
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, m) {
+		t.Error("mapping changed in a JSON round trip")
+	}
+	if err := json.Unmarshal([]byte(`{"categories": {"*": {"Films": "movies"}}}`), &decoded); err == nil {
+		t.Error("unknown category accepted")
+	}
+}
+
+func TestCategoryResolver(t *testing.T) {
+	r := NewCategoryResolver(DefaultCategoryMapping())
+	r.AddVenueCategory(VenueCategoryV0{VenueID: VenueIDKalshi, ID: "Politics", Name: "Politics", Subcategories: []string{"Policy", "International"}})
+	r.AddVenueCategory(VenueCategoryV0{VenueID: VenueIDPolymarket, ID: "tag_btc", Name: "BTC", Slug: stringPtr("btc"), ParentID: stringPtr("tag_crypto")})
+	r.AddVenueCategory(VenueCategoryV0{VenueID: VenueIDPolymarket, ID: "tag_crypto", Name: "Crypto"})
+
+	series := SeriesMetadataV0{VenueID: VenueIDKalshi, EventID: "KXFED", Tags: []string{"rates", "Fed"}}
+	tests := []struct {
+		name   string
+		event  EventMetadataV0
+		parent *SeriesMetadataV0
+		want   CategoryMatch
+	}{
+		{"category", EventMetadataV0{VenueID: VenueIDKalshi, Category: stringPtr("Sports")}, nil, CategoryMatch{CategorySports, SourceCategory, "Sports", ""}},
+		{"subcategory", EventMetadataV0{VenueID: VenueIDKalshi, Category: stringPtr("Policy")}, nil, CategoryMatch{CategoryPolitics, SourceCategory, "Policy", "Politics"}},
+		{"parent tag by ID", EventMetadataV0{VenueID: VenueIDPolymarket, Tags: []string{"trending", "btc"}}, nil, CategoryMatch{CategoryCrypto, SourceTag, "btc", "Crypto"}},
+		{"parent series", EventMetadataV0{VenueID: VenueIDKalshi, Category: stringPtr("Unknown")}, &series, CategoryMatch{CategoryEconomics, SourceParentSeries, "Fed", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.ResolveEvent(tt.event, tt.parent)
+			if !ok || got != tt.want {
+				t.Errorf("ResolveEvent() = %v, %v; want %v", got, ok, tt.want)
+			}
+		})
+	}
+	if got, ok := r.ResolveEvent(EventMetadataV0{VenueID: VenueIDKalshi, Category: stringPtr("Culture")}, nil); ok {
+		t.Errorf("ResolveEvent(Culture) = %v", got)
+	}
+}
+
+func TestCategoryResolver_Catalog(t *testing.T) {
+	c := NewCatalog()
+	series := SeriesDiscoveryPayloadV0{
+		Event: SeriesMetadataV0{
+			Kind: DiscoveryKindSeries, VenueID: VenueIDKalshi, EventID: "KXFED", Title: "Fed decisions",
+			Category: stringPtr("Economics"), Active: boolPtr(true), Closed: boolPtr(false),
+			DiscoveredAt: catalogT0, LastSeen: catalogT0,
+		},
+		EventID: "evt_KXFED", EventType: EventTypeDiscovered, Timestamp: catalogT0, VenueID: VenueIDKalshi,
+	}
+	if err := c.ApplySeries(series); err != nil {
+		t.Fatal(err)
+	}
+	child := catalogEvent("FED-25DEC", EventTypeDiscovered, catalogT0, "fed")
+	child.Event.ParentSeriesID = stringPtr("KXFED")
+	other := catalogEvent("NBA-FINALS", EventTypeDiscovered, catalogT0, "nba")
+	other.Event.Tags = []string{"NBA"}
+	for _, p := range []EventDiscoveryPayloadV0{child, other} {
+		if err := c.ApplyEvent(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewCategoryResolver(DefaultCategoryMapping())
+	got := r.LiveInCategory(c, CategoryEconomics)
+	if len(got) != 1 || got[0].Ref.EventID != "FED-25DEC" {
+		t.Errorf("LiveInCategory(economics) = %v", got)
+	}
+	if got := r.LiveInCategory(c, CategorySports); len(got) != 1 || got[0].Ref.EventID != "NBA-FINALS" {
+		t.Errorf("LiveInCategory(sports) = %v", got)
+	}
+}
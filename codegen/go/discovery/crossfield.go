@@ -0,0 +1,183 @@
+package discovery
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Cross-field rules check that fields of one payload agree with each other.
+// Each can be disabled or downgraded to a warning on a Validator.
+const (
+	RuleVenueIDMatch  RuleCode = "venue_id_match" // payload venue_id equals event.venue_id
+	RuleEventIDMatch  RuleCode = "event_id_match" // payload event_id equals event.event_id (off by default)
+	RuleDateOrder     RuleCode = "date_order"     // event.start_date <= event.end_date
+	RuleSeenOrder     RuleCode = "seen_order"     // event.discovered_at <= event.last_seen
+	RuleBatchSequence RuleCode = "batch_sequence" // batch_sequence <= batch_total_count
+	RuleURL           RuleCode = "url"            // contract, settlement and image URLs are absolute
+)
+
+// CrossFieldRules lists every cross-field rule
+func CrossFieldRules() []RuleCode {
+	return []RuleCode{RuleVenueIDMatch, RuleEventIDMatch, RuleDateOrder, RuleSeenOrder, RuleBatchSequence, RuleURL}
+}
+
+// Validator validates discovery payloads: the per-field checks, which are
+// always applied, plus the enabled cross-field rules. The zero value enables
+// every cross-field rule. Validator values are immutable; With, Without and
+// WithSeverity return modified copies.
+type Validator struct {
+	disabled map[RuleCode]bool
+	severity map[RuleCode]Severity
+}
+
+// NewValidator returns a Validator with every cross-field rule enabled at
+// SeverityError except RuleEventIDMatch. The schemas describe the payload
+// event_id as the identifier of the discovery message, so producers that
+// mint a message ID, as in schemas/examples/discovery, fail that rule.
+func NewValidator() Validator {
+	return Validator{}.Without(RuleEventIDMatch)
+}
+
+// FieldOnly returns a Validator with every cross-field rule disabled. The
+// package-level Validate* and Check* functions use it.
+func FieldOnly() Validator {
+	return Validator{}.Without(CrossFieldRules()...)
+}
+
+// Without returns a copy of v with the given rules disabled
+func (v Validator) Without(rules ...RuleCode) Validator {
+	disabled := make(map[RuleCode]bool, len(v.disabled)+len(rules))
+	for rule := range v.disabled {
+		disabled[rule] = true
+	}
+	for _, rule := range rules {
+		disabled[rule] = true
+	}
+	v.disabled = disabled
+	return v
+}
+
+// With returns a copy of v with the given rules enabled
+func (v Validator) With(rules ...RuleCode) Validator {
+	disabled := make(map[RuleCode]bool, len(v.disabled))
+	for rule := range v.disabled {
+		disabled[rule] = true
+	}
+	for _, rule := range rules {
+		delete(disabled, rule)
+	}
+	v.disabled = disabled
+	return v
+}
+
+// WithSeverity returns a copy of v that reports rule violations at severity.
+// Warnings are kept in the report but do not fail validation.
+func (v Validator) WithSeverity(rule RuleCode, severity Severity) Validator {
+	bySeverity := make(map[RuleCode]Severity, len(v.severity)+1)
+	for r, s := range v.severity {
+		bySeverity[r] = s
+	}
+	bySeverity[rule] = severity
+	v.severity = bySeverity
+	return v
+}
+
+// Enabled reports whether a cross-field rule is applied
+func (v Validator) Enabled(rule RuleCode) bool {
+	return !v.disabled[rule]
+}
+
+// CheckEventDiscoveryPayload returns every field and cross-field violation
+func (v Validator) CheckEventDiscoveryPayload(p EventDiscoveryPayloadV0) *ValidationReport {
+	r := checkEventDiscoveryFields(p)
+	v.checkPayloadIDs(r, p.VenueID, p.Event.VenueID, p.EventID, p.Event.EventID)
+	if p.Event.StartDate != nil && p.Event.EndDate != nil && p.Event.StartDate.After(*p.Event.EndDate) {
+		v.report(r, "/event/end_date", RuleDateOrder, fmt.Sprintf("end_date %s is before start_date %s",
+			p.Event.EndDate.Format(time.RFC3339), p.Event.StartDate.Format(time.RFC3339)))
+	}
+	v.checkSeen(r, "/event", p.Event.DiscoveredAt, p.Event.LastSeen)
+	v.checkBatch(r, p.DiscoveryMeta)
+	return r
+}
+
+// ValidateEventDiscoveryPayload unmarshals and validates an event discovery payload
+func (v Validator) ValidateEventDiscoveryPayload(payload []byte) error {
+	p, err := unmarshalPayload[EventDiscoveryPayloadV0](payload)
+	if err != nil {
+		return err
+	}
+	return v.CheckEventDiscoveryPayload(p).Err()
+}
+
+// CheckSeriesDiscoveryPayload returns every field and cross-field violation
+func (v Validator) CheckSeriesDiscoveryPayload(p SeriesDiscoveryPayloadV0) *ValidationReport {
+	r := checkSeriesDiscoveryFields(p)
+	v.checkPayloadIDs(r, p.VenueID, p.Event.VenueID, p.EventID, p.Event.EventID)
+	v.checkSeen(r, "/event", p.Event.DiscoveredAt, p.Event.LastSeen)
+	v.checkBatch(r, p.DiscoveryMeta)
+	if data := p.Event.SeriesData; data != nil {
+		v.checkURL(r, "/event/series_data/image_url", data.ImageURL)
+		v.checkURL(r, "/event/series_data/icon_url", data.IconURL)
+		if c := data.Contract; c != nil {
+			v.checkURL(r, "/event/series_data/contract/contract_url", c.ContractURL)
+			v.checkURL(r, "/event/series_data/contract/contract_terms_url", c.ContractTermsURL)
+			for i, source := range c.SettlementSources {
+				v.checkURL(r, pointer("/event/series_data/contract/settlement_sources", i, "url"), source.URL)
+			}
+		}
+	}
+	return r
+}
+
+// ValidateSeriesDiscoveryPayload unmarshals and validates a series discovery payload
+func (v Validator) ValidateSeriesDiscoveryPayload(payload []byte) error {
+	p, err := unmarshalPayload[SeriesDiscoveryPayloadV0](payload)
+	if err != nil {
+		return err
+	}
+	return v.CheckSeriesDiscoveryPayload(p).Err()
+}
+
+func (v Validator) checkPayloadIDs(r *ValidationReport, venueID, eventVenueID VenueID, eventID, eventEventID string) {
+	if venueID != eventVenueID {
+		v.report(r, "/event/venue_id", RuleVenueIDMatch, fmt.Sprintf("%q does not match payload venue_id %q", eventVenueID, venueID))
+	}
+	if eventID != eventEventID {
+		v.report(r, "/event/event_id", RuleEventIDMatch, fmt.Sprintf("%q does not match payload event_id %q", eventEventID, eventID))
+	}
+}
+
+func (v Validator) checkSeen(r *ValidationReport, path string, discoveredAt, lastSeen time.Time) {
+	if !discoveredAt.IsZero() && !lastSeen.IsZero() && discoveredAt.After(lastSeen) {
+		v.report(r, pointer(path, "last_seen"), RuleSeenOrder, fmt.Sprintf("last_seen %s is before discovered_at %s",
+			lastSeen.Format(time.RFC3339), discoveredAt.Format(time.RFC3339)))
+	}
+}
+
+func (v Validator) checkBatch(r *ValidationReport, meta *DiscoveryMetaV0) {
+	if meta != nil && meta.BatchSequence > meta.BatchTotalCount {
+		v.report(r, "/discovery_meta/batch_sequence", RuleBatchSequence,
+			fmt.Sprintf("%d exceeds batch_total_count %d", meta.BatchSequence, meta.BatchTotalCount))
+	}
+}
+
+func (v Validator) checkURL(r *ValidationReport, path string, raw *string) {
+	if raw == nil {
+		return
+	}
+	if u, err := url.Parse(*raw); err != nil || u.Scheme == "" || u.Host == "" {
+		v.report(r, path, RuleURL, fmt.Sprintf("%q is not an absolute URL", *raw))
+	}
+}
+
+func (v Validator) report(r *ValidationReport, path string, rule RuleCode, message string) {
+	if !v.Enabled(rule) {
+		return
+	}
+	severity, ok := v.severity[rule]
+	if !ok {
+		severity = SeverityError
+	}
+	r.addWithSeverity(path, rule, severity, message)
+}
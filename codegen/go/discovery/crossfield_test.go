@@ -0,0 +1,131 @@
+package discovery
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestValidator_ValidExamples(t *testing.T) {
+	v := NewValidator()
+	for _, name := range []string{"event-payload-polymarket-valid.json", "event-payload-kalshi-valid.json", "minimal-event-payload.json"} {
+		if err := v.ValidateEventDiscoveryPayload(loadTestData(t, name)); err != nil {
+			t.Errorf("%s: ValidateEventDiscoveryPayload() error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"series-payload-polymarket-valid.json", "series-payload-kalshi-valid.json", "minimal-series-payload.json"} {
+		if err := v.ValidateSeriesDiscoveryPayload(loadTestData(t, name)); err != nil {
+			t.Errorf("%s: ValidateSeriesDiscoveryPayload() error = %v", name, err)
+		}
+	}
+}
+
+func contradictoryEventPayload() EventDiscoveryPayloadV0 {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	start, end := now.Add(48*time.Hour), now.Add(24*time.Hour)
+	return EventDiscoveryPayloadV0{
+		Event: EventMetadataV0{
+			Kind:         DiscoveryKindEvent,
+			VenueID:      VenueIDKalshi,
+			EventID:      "PRES-28",
+			Title:        "Presidential Election 2028",
+			Active:       boolPtr(true),
+			Closed:       boolPtr(false),
+			StartDate:    &start,
+			EndDate:      &end,
+			DiscoveredAt: now,
+			LastSeen:     now.Add(-time.Hour),
+		},
+		EventID:       "evt_1",
+		EventType:     EventTypeDiscovered,
+		Timestamp:     now,
+		VenueID:       VenueIDPolymarket,
+		DiscoveryMeta: &DiscoveryMetaV0{BatchID: "b", BatchSequence: 5, BatchTotalCount: 3, DiscoveryRunID: "r"},
+	}
+}
+
+func rulesOf(r *ValidationReport) map[RuleCode]Severity {
+	out := make(map[RuleCode]Severity)
+	for _, v := range r.Violations {
+		out[v.Rule] = v.Severity
+	}
+	return out
+}
+
+func TestValidator_EventRules(t *testing.T) {
+	p := contradictoryEventPayload()
+
+	if r := CheckEventDiscoveryPayload(p); len(r.Violations) != 0 {
+		t.Errorf("field-only check reported %v", r.Violations)
+	}
+
+	got := rulesOf(NewValidator().CheckEventDiscoveryPayload(p))
+	for _, rule := range []RuleCode{RuleVenueIDMatch, RuleDateOrder, RuleSeenOrder, RuleBatchSequence} {
+		if got[rule] != SeverityError {
+			t.Errorf("rule %s: severity = %q, want error", rule, got[rule])
+		}
+	}
+	if _, ok := got[RuleEventIDMatch]; ok {
+		t.Error("event_id_match is on by default")
+	}
+
+	got = rulesOf(NewValidator().With(RuleEventIDMatch).Without(RuleDateOrder).CheckEventDiscoveryPayload(p))
+	if _, ok := got[RuleDateOrder]; ok {
+		t.Error("disabled date_order rule still reported")
+	}
+	if got[RuleEventIDMatch] != SeverityError {
+		t.Error("enabled event_id_match rule not reported")
+	}
+
+	data, _ := json.Marshal(p)
+	v := FieldOnly().With(RuleSeenOrder).WithSeverity(RuleSeenOrder, SeverityWarning)
+	if err := v.ValidateEventDiscoveryPayload(data); err != nil {
+		t.Errorf("warning-only rule failed validation: %v", err)
+	}
+	if r := v.CheckEventDiscoveryPayload(p); len(r.Warnings()) != 1 || r.Warnings()[0].Path != "/event/last_seen" {
+		t.Errorf("Warnings() = %v", r.Warnings())
+	}
+}
+
+func TestValidator_SeriesURLs(t *testing.T) {
+	now := time.Now()
+	p := SeriesDiscoveryPayloadV0{
+		Event: SeriesMetadataV0{
+			Kind:         DiscoveryKindSeries,
+			VenueID:      VenueIDKalshi,
+			EventID:      "KXPRES",
+			Title:        "Presidential Election",
+			Active:       boolPtr(true),
+			Closed:       boolPtr(false),
+			DiscoveredAt: now,
+			LastSeen:     now,
+			SeriesData: &SeriesDataV0{
+				ImageURL: stringPtr("https://kalshi.com/img.png"),
+				Contract: &ContractDataV0{
+					ContractURL: stringPtr("not a url"),
+					SettlementSources: []SettlementSourceV0{
+						{Name: "AP", URL: stringPtr("https://apnews.com")},
+						{Name: "Reuters", URL: stringPtr("reuters.com")},
+					},
+				},
+			},
+		},
+		EventID:   "evt_series_1",
+		EventType: EventTypeUpdated,
+		Timestamp: now,
+		VenueID:   VenueIDKalshi,
+	}
+
+	r := NewValidator().CheckSeriesDiscoveryPayload(p)
+	var paths []string
+	for _, v := range r.Violations {
+		paths = append(paths, v.Path)
+	}
+	want := []string{"/event/series_data/contract/contract_url", "/event/series_data/contract/settlement_sources/1/url"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("violations at %v, want %v", paths, want)
+	}
+	if r := NewValidator().Without(RuleURL).CheckSeriesDiscoveryPayload(p); len(r.Violations) != 0 {
+		t.Errorf("disabled url rule still reported %v", r.Violations)
+	}
+}
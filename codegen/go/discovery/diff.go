@@ -0,0 +1,262 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind says how a field changed between two metadata snapshots
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// FieldChange is one changed field. Path is its JSON Pointer, Old and New
+// its JSON values (nil when absent).
+type FieldChange struct {
+	Path     string
+	Kind     ChangeKind
+	Old      any
+	New      any
+	Material bool
+}
+
+// MetadataDiff lists the field changes between two snapshots, sorted by path
+type MetadataDiff struct {
+	Changes []FieldChange
+}
+
+// Material reports whether any change is material
+func (d MetadataDiff) Material() bool {
+	for _, c := range d.Changes {
+		if c.Material {
+			return true
+		}
+	}
+	return false
+}
+
+// MaterialChanges returns the material changes
+func (d MetadataDiff) MaterialChanges() []FieldChange {
+	var out []FieldChange
+	for _, c := range d.Changes {
+		if c.Material {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Materiality decides which changed fields are material. Fields are matched
+// by JSON Pointer pattern: a pattern covers the field it names and everything
+// below it, and "*" matches any single token, e.g. "/extra_metadata/*/volume".
+// When several patterns match, the longest wins, then the one with more
+// literal tokens ("/a/b" beats "/a/*"), then the last registered; unmatched
+// fields are material. Materiality values are immutable.
+type Materiality struct {
+	rules []materialityRule // in registration order
+}
+
+type materialityRule struct {
+	pattern  string
+	depth    int // tokens in pattern
+	literals int // tokens other than "*"
+	material bool
+}
+
+// DefaultMateriality treats last_seen, discovered_at, financial figures and
+// venue timestamps as immaterial, so a re-listing that only refreshes them
+// does not produce an update
+func DefaultMateriality() Materiality {
+	return Materiality{}.Ignore(
+		"/last_seen",
+		"/discovered_at",
+		"/series_data/financial",
+		"/series_data/timestamps",
+	)
+}
+
+// Ignore returns a copy of m that treats the given fields as immaterial
+func (m Materiality) Ignore(patterns ...string) Materiality {
+	return m.with(false, patterns)
+}
+
+// Include returns a copy of m that treats the given fields as material, e.g.
+// to re-include one field below an ignored object
+func (m Materiality) Include(patterns ...string) Materiality {
+	return m.with(true, patterns)
+}
+
+// with appends the patterns; re-registering a pattern moves it to the end
+func (m Materiality) with(material bool, patterns []string) Materiality {
+	replaced := make(map[string]bool, len(patterns))
+	for _, p := range patterns {
+		replaced[p] = true
+	}
+	rules := make([]materialityRule, 0, len(m.rules)+len(patterns))
+	for _, r := range m.rules {
+		if !replaced[r.pattern] {
+			rules = append(rules, r)
+		}
+	}
+	for _, p := range patterns {
+		if !replaced[p] {
+			continue // duplicate within patterns
+		}
+		delete(replaced, p)
+		r := materialityRule{pattern: p, material: material}
+		for _, tok := range strings.Split(p, "/")[1:] {
+			r.depth++
+			if tok != "*" {
+				r.literals++
+			}
+		}
+		rules = append(rules, r)
+	}
+	return Materiality{rules: rules}
+}
+
+// IsMaterial reports whether a change at path is material
+func (m Materiality) IsMaterial(path string) bool {
+	var best *materialityRule
+	for i := range m.rules {
+		r := &m.rules[i]
+		if !matchPointer(r.pattern, path) {
+			continue
+		}
+		if best == nil || r.depth > best.depth || r.depth == best.depth && r.literals >= best.literals {
+			best = r
+		}
+	}
+	return best == nil || best.material
+}
+
+// matchPointer reports whether pattern names path or one of its ancestors
+func matchPointer(pattern, path string) bool {
+	if pattern == "" {
+		return true
+	}
+	pt := strings.Split(pattern, "/")
+	tokens := strings.Split(path, "/")
+	if len(pt) > len(tokens) {
+		return false
+	}
+	for i, p := range pt {
+		if p != "*" && p != tokens[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffEventMetadata compares two event metadata snapshots
+func DiffEventMetadata(prev, next EventMetadataV0, m Materiality) (MetadataDiff, error) {
+	return diffMetadata(prev, next, m)
+}
+
+// DiffSeriesMetadata compares two series metadata snapshots, including
+// series_data and extra_metadata
+func DiffSeriesMetadata(prev, next SeriesMetadataV0, m Materiality) (MetadataDiff, error) {
+	return diffMetadata(prev, next, m)
+}
+
+// NextEventType decides which discovery event, if any, to emit for next given
+// the previously published snapshot: discovered when there is none, updated
+// when a material field changed, and nothing otherwise
+func NextEventType[T EventMetadataV0 | SeriesMetadataV0](prev *T, next T, m Materiality) (EventType, bool, error) {
+	if prev == nil {
+		return EventTypeDiscovered, true, nil
+	}
+	d, err := diffMetadata(*prev, next, m)
+	if err != nil {
+		return "", false, err
+	}
+	if !d.Material() {
+		return "", false, nil
+	}
+	return EventTypeUpdated, true, nil
+}
+
+func diffMetadata[T any](prev, next T, m Materiality) (MetadataDiff, error) {
+	a, err := toJSONValue(prev)
+	if err != nil {
+		return MetadataDiff{}, err
+	}
+	b, err := toJSONValue(next)
+	if err != nil {
+		return MetadataDiff{}, err
+	}
+	var d MetadataDiff
+	diffValues("", a, b, m, &d)
+	sort.SliceStable(d.Changes, func(i, j int) bool { return d.Changes[i].Path < d.Changes[j].Path })
+	return d, nil
+}
+
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return out, nil
+}
+
+func diffValues(path string, a, b any, m Materiality, d *MetadataDiff) {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			keys := make(map[string]bool, len(av)+len(bv))
+			for k := range av {
+				keys[k] = true
+			}
+			for k := range bv {
+				keys[k] = true
+			}
+			for k := range keys {
+				child := pointer(path, k)
+				old, inA := av[k]
+				cur, inB := bv[k]
+				switch {
+				case !inA:
+					d.add(child, ChangeAdded, nil, cur, m)
+				case !inB:
+					d.add(child, ChangeRemoved, old, nil, m)
+				default:
+					diffValues(child, old, cur, m, d)
+				}
+			}
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			for i := 0; i < len(av) || i < len(bv); i++ {
+				child := pointer(path, i)
+				switch {
+				case i >= len(av):
+					d.add(child, ChangeAdded, nil, bv[i], m)
+				case i >= len(bv):
+					d.add(child, ChangeRemoved, av[i], nil, m)
+				default:
+					diffValues(child, av[i], bv[i], m, d)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		d.add(path, ChangeModified, a, b, m)
+	}
+}
+
+func (d *MetadataDiff) add(path string, kind ChangeKind, old, cur any, m Materiality) {
+	d.Changes = append(d.Changes, FieldChange{Path: path, Kind: kind, Old: old, New: cur, Material: m.IsMaterial(path)})
+}
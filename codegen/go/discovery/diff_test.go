@@ -0,0 +1,146 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func diffSeries() SeriesMetadataV0 {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	return SeriesMetadataV0{
+		Kind:         DiscoveryKindSeries,
+		VenueID:      VenueIDKalshi,
+		EventID:      "KXPRES",
+		Title:        "Presidential Election",
+		Active:       boolPtr(true),
+		Closed:       boolPtr(false),
+		Tags:         []string{"politics"},
+		DiscoveredAt: now,
+		LastSeen:     now,
+		SeriesData: &SeriesDataV0{
+			Ticker:    stringPtr("KXPRES"),
+			Financial: &FinancialDataV0{Volume24hUSD: floatPtr(100)},
+			Contract:  &ContractDataV0{SettlementSources: []SettlementSourceV0{{Name: "AP"}}},
+		},
+		ExtraMetadata: map[string]any{"frequency": "custom", "stats": map[string]any{"views": 10.0}},
+	}
+}
+
+func TestDiffSeriesMetadata_OnlyLastSeen(t *testing.T) {
+	prev, next := diffSeries(), diffSeries()
+	next.LastSeen = next.LastSeen.Add(time.Hour)
+	next.SeriesData.Financial.Volume24hUSD = floatPtr(250)
+
+	d, err := DiffSeriesMetadata(prev, next, DefaultMateriality())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Changes) != 2 || d.Material() {
+		t.Errorf("Changes = %+v, want two immaterial changes", d.Changes)
+	}
+	if eventType, emit, _ := NextEventType(&prev, next, DefaultMateriality()); emit {
+		t.Errorf("NextEventType() = %s, want no update", eventType)
+	}
+}
+
+func TestDiffSeriesMetadata_NestedChanges(t *testing.T) {
+	prev, next := diffSeries(), diffSeries()
+	next.Title = "Presidential Election 2028"
+	next.Tags = append(next.Tags, "elections")
+	next.SeriesData.Contract.SettlementSources[0].URL = stringPtr("https://apnews.com")
+	next.SeriesData.Ticker = nil
+	next.ExtraMetadata = map[string]any{"frequency": "custom", "stats": map[string]any{"views": 11.0}}
+
+	m := DefaultMateriality().Ignore("/extra_metadata/*/views")
+	d, err := DiffSeriesMetadata(prev, next, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type change struct {
+		Path     string
+		Kind     ChangeKind
+		Material bool
+	}
+	var got []change
+	for _, c := range d.Changes {
+		got = append(got, change{c.Path, c.Kind, c.Material})
+	}
+	want := []change{
+		{"/extra_metadata/stats/views", ChangeModified, false},
+		{"/series_data/contract/settlement_sources/0/url", ChangeAdded, true},
+		{"/series_data/ticker", ChangeRemoved, true},
+		{"/tags/1", ChangeAdded, true},
+		{"/title", ChangeModified, true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Changes = %v, want %v", got, want)
+	}
+	if d.Changes[4].Old != "Presidential Election" || d.Changes[4].New != "Presidential Election 2028" {
+		t.Errorf("title change = %+v", d.Changes[4])
+	}
+
+	if eventType, emit, _ := NextEventType(&prev, next, m); !emit || eventType != EventTypeUpdated {
+		t.Errorf("NextEventType() = %s, %v", eventType, emit)
+	}
+	if eventType, _, _ := NextEventType[SeriesMetadataV0](nil, next, m); eventType != EventTypeDiscovered {
+		t.Errorf("NextEventType(nil) = %s", eventType)
+	}
+}
+
+func TestMateriality(t *testing.T) {
+	m := Materiality{}.Ignore("/series_data").Include("/series_data/contract")
+	tests := map[string]bool{
+		"/title":                              true,
+		"/series_data/ticker":                 false,
+		"/series_data/contract/contract_url":  true,
+		"/series_data_extra":                  true,
+		"/series_data/contract_terms_ignored": false,
+	}
+	for path, want := range tests {
+		if got := m.IsMaterial(path); got != want {
+			t.Errorf("IsMaterial(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestMateriality_EqualDepthTieBreak(t *testing.T) {
+	// A literal token beats "*" whichever was registered first
+	for _, m := range []Materiality{
+		Materiality{}.Ignore("/extra_metadata/*").Include("/extra_metadata/title"),
+		Materiality{}.Include("/extra_metadata/title").Ignore("/extra_metadata/*"),
+	} {
+		for i := 0; i < 20; i++ {
+			if !m.IsMaterial("/extra_metadata/title") || m.IsMaterial("/extra_metadata/views") {
+				t.Fatalf("literal token should beat * at equal depth: %+v", m.rules)
+			}
+		}
+	}
+
+	// Among equally specific patterns the last registered wins
+	m := Materiality{}.Ignore("/a/*/c").Include("/a/b/*")
+	for i := 0; i < 20; i++ {
+		if !m.IsMaterial("/a/b/c") {
+			t.Fatal("last registered pattern should win a tie")
+		}
+	}
+	if m.Ignore("/a/b/*").IsMaterial("/a/b/c") {
+		t.Error("re-registering a pattern should make it the last registered")
+	}
+}
+
+func TestDiffEventMetadata(t *testing.T) {
+	prev := EventMetadataV0{Kind: DiscoveryKindEvent, VenueID: VenueIDKalshi, EventID: "PRES-28", Active: boolPtr(true)}
+	next := prev
+	next.Active = boolPtr(false)
+
+	d, err := DiffEventMetadata(prev, next, DefaultMateriality())
+	if err != nil || len(d.MaterialChanges()) != 1 || d.Changes[0].Path != "/active" {
+		t.Errorf("DiffEventMetadata() = %+v, %v", d, err)
+	}
+
+	next.ExtraMetadata = map[string]any{"bad": func() {}}
+	if _, err := DiffEventMetadata(prev, next, DefaultMateriality()); err == nil {
+		t.Error("DiffEventMetadata() expected encode error")
+	}
+}
@@ -31,5 +31,8 @@ const (
 type Currency string
 
 const (
-	CurrencyUSD Currency = "USD"
+	CurrencyUSD  Currency = "USD"
+	CurrencyUSDC Currency = "USDC"
+	CurrencyEUR  Currency = "EUR"
+	CurrencyGBP  Currency = "GBP"
 )
\ No newline at end of file
@@ -0,0 +1,113 @@
+package discovery
+
+import (
+	"fmt"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/money"
+)
+
+// IsValid reports whether c is in the default currency registry
+func (c Currency) IsValid() bool {
+	return money.IsValidCurrency(money.Currency(c))
+}
+
+// MinorUnits returns the number of decimal places amounts in c may carry
+func (c Currency) MinorUnits() (int32, bool) {
+	return money.Currency(c).MinorUnits()
+}
+
+// FinancialAmounts holds the monetary fields of FinancialDataV0 as exact
+// money. Fields absent from the financial data are nil.
+type FinancialAmounts struct {
+	Volume24h      *money.Money
+	VolumeTotal    *money.Money
+	LiquidityTotal *money.Money
+}
+
+// currency returns the currency of the *_native fields, USD when unset
+func (f FinancialDataV0) currency() money.Currency {
+	if f.Currency == nil {
+		return money.CurrencyUSD
+	}
+	return money.Currency(*f.Currency)
+}
+
+// Amounts returns the *_usd fields as exact USD. Values finer than a cent are
+// rejected with a *money.PrecisionError.
+func (f FinancialDataV0) Amounts() (FinancialAmounts, error) {
+	return amounts(money.CurrencyUSD, f.Volume24hUSD, f.VolumeTotalUSD, f.LiquidityTotalUSD)
+}
+
+// NativeAmounts returns the *_native fields as exact money in the data's
+// currency (USD when unset). Values finer than the currency's minor units are
+// rejected with a *money.PrecisionError.
+func (f FinancialDataV0) NativeAmounts() (FinancialAmounts, error) {
+	return amounts(f.currency(), f.Volume24hNative, f.VolumeTotalNative, f.LiquidityTotalNative)
+}
+
+func amounts(currency money.Currency, volume24h, volumeTotal, liquidityTotal *float64) (FinancialAmounts, error) {
+	var out FinancialAmounts
+	for _, field := range []struct {
+		value *float64
+		dst   **money.Money
+	}{
+		{volume24h, &out.Volume24h},
+		{volumeTotal, &out.VolumeTotal},
+		{liquidityTotal, &out.LiquidityTotal},
+	} {
+		if field.value == nil {
+			continue
+		}
+		amount, err := money.FromFloat(*field.value)
+		if err != nil {
+			return FinancialAmounts{}, err
+		}
+		m, err := money.NewMoney(amount, currency)
+		if err != nil {
+			return FinancialAmounts{}, err
+		}
+		*field.dst = &m
+	}
+	return out, nil
+}
+
+// ToUSD returns a copy of the financial data with each *_usd field that has a
+// *_native counterpart set to that amount converted to USD cents by fx. The
+// native fields and Currency are kept. fx is only used for non-USD data and
+// may be nil otherwise.
+func (f FinancialDataV0) ToUSD(fx money.FXConverter) (FinancialDataV0, error) {
+	native, err := f.NativeAmounts()
+	if err != nil {
+		return FinancialDataV0{}, err
+	}
+	out := f
+	for _, field := range []struct {
+		amount *money.Money
+		dst    **float64
+	}{
+		{native.Volume24h, &out.Volume24hUSD},
+		{native.VolumeTotal, &out.VolumeTotalUSD},
+		{native.LiquidityTotal, &out.LiquidityTotalUSD},
+	} {
+		if field.amount == nil {
+			continue
+		}
+		usd, err := convertToUSD(*field.amount, fx)
+		if err != nil {
+			return FinancialDataV0{}, err
+		}
+		value := usd.Float64()
+		*field.dst = &value
+	}
+	return out, nil
+}
+
+func convertToUSD(amount money.Money, fx money.FXConverter) (money.Money, error) {
+	if amount.Currency() == money.CurrencyUSD {
+		return amount, nil
+	}
+	if fx == nil {
+		return money.Money{}, fmt.Errorf("%w: no FX converter for %s amounts", money.ErrNoRate, amount.Currency())
+	}
+	return fx.Convert(amount, money.CurrencyUSD)
+}
@@ -0,0 +1,99 @@
+package discovery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/money"
+)
+
+func TestFinancialDataV0_Amounts(t *testing.T) {
+	f := FinancialDataV0{Volume24hUSD: floatPtr(125000.75), LiquidityTotalUSD: floatPtr(0.1)}
+	amounts, err := f.Amounts()
+	if err != nil {
+		t.Fatalf("Amounts() error = %v", err)
+	}
+	if amounts.Volume24h == nil || !amounts.Volume24h.Equal(money.MustUSD("125000.75")) {
+		t.Errorf("Volume24h = %v, want 125000.75 USD", amounts.Volume24h)
+	}
+	if amounts.LiquidityTotal == nil || amounts.LiquidityTotal.String() != "0.10 USD" {
+		t.Errorf("LiquidityTotal = %v, want 0.10 USD", amounts.LiquidityTotal)
+	}
+	if amounts.VolumeTotal != nil {
+		t.Errorf("VolumeTotal = %v, want nil", amounts.VolumeTotal)
+	}
+
+	f.VolumeTotalUSD = floatPtr(100.001)
+	_, err = f.Amounts()
+	var perr *money.PrecisionError
+	if !errors.As(err, &perr) {
+		t.Errorf("Amounts() error = %v, want *money.PrecisionError", err)
+	}
+}
+
+func TestFinancialDataV0_NativeAmounts(t *testing.T) {
+	f := FinancialDataV0{Volume24hNative: floatPtr(1000.123456), Volume24hUSD: floatPtr(999.92), Currency: stringPtr(string(CurrencyUSDC))}
+	native, err := f.NativeAmounts()
+	if err != nil || native.Volume24h == nil || native.Volume24h.String() != "1000.123456 USDC" {
+		t.Errorf("NativeAmounts() = %v, %v, want 1000.123456 USDC", native.Volume24h, err)
+	}
+	usd, err := f.Amounts()
+	if err != nil || usd.Volume24h == nil || usd.Volume24h.String() != "999.92 USD" {
+		t.Errorf("Amounts() = %v, %v, want 999.92 USD whatever the native currency", usd.Volume24h, err)
+	}
+}
+
+func TestFinancialDataV0_ToUSD(t *testing.T) {
+	f := FinancialDataV0{
+		Volume24hNative:      floatPtr(1000.123456),
+		LiquidityTotalNative: floatPtr(0.004999),
+		VolumeTotalUSD:       floatPtr(12.5),
+		Volume24hContracts:   intPtr(10),
+		Currency:             stringPtr(string(CurrencyUSDC)),
+	}
+	fx := money.NewFixedRates().WithRate(money.CurrencyUSDC, money.CurrencyUSD, money.MustParse("0.9998"))
+
+	usd, err := f.ToUSD(fx)
+	if err != nil {
+		t.Fatalf("ToUSD() error = %v", err)
+	}
+	if usd.Currency == nil || *usd.Currency != "USDC" || *usd.Volume24hNative != 1000.123456 {
+		t.Errorf("ToUSD() changed the native amounts: currency %v, volume %v", usd.Currency, *usd.Volume24hNative)
+	}
+	// 1000.123456 × 0.9998 = 999.9234313088, 0.004999 × 0.9998 rounds to 0.00;
+	// volume_total_usd has no native counterpart and is kept
+	if *usd.Volume24hUSD != 999.92 || *usd.LiquidityTotalUSD != 0 || *usd.VolumeTotalUSD != 12.5 {
+		t.Errorf("ToUSD() = %v / %v / %v", *usd.Volume24hUSD, *usd.VolumeTotalUSD, *usd.LiquidityTotalUSD)
+	}
+	if *usd.Volume24hContracts != 10 || f.Volume24hUSD != nil {
+		t.Error("ToUSD() changed unrelated fields or its receiver")
+	}
+	if err := ValidateFinancialData(usd); err != nil {
+		t.Errorf("converted data fails validation: %v", err)
+	}
+
+	f.Currency = stringPtr(string(CurrencyEUR))
+	f.Volume24hNative = floatPtr(10)
+	f.LiquidityTotalNative = nil
+	if _, err := f.ToUSD(fx); !errors.Is(err, money.ErrNoRate) {
+		t.Errorf("ToUSD() error = %v, want ErrNoRate", err)
+	}
+	if _, err := f.ToUSD(nil); !errors.Is(err, money.ErrNoRate) {
+		t.Errorf("ToUSD(nil) on EUR data error = %v, want ErrNoRate", err)
+	}
+
+	plain := FinancialDataV0{Volume24hNative: floatPtr(5)}
+	got, err := plain.ToUSD(nil)
+	if err != nil || got.Volume24hUSD == nil || *got.Volume24hUSD != 5 {
+		t.Errorf("ToUSD(nil) on USD data = %+v, %v", got, err)
+	}
+}
+
+func TestCurrency_Registry(t *testing.T) {
+	if !CurrencyUSD.IsValid() || !CurrencyUSDC.IsValid() || Currency("XYZ").IsValid() {
+		t.Error("IsValid() disagrees with the default currency registry")
+	}
+	if places, ok := CurrencyUSDC.MinorUnits(); !ok || places != 6 {
+		t.Errorf("USDC MinorUnits() = %d, %v, want 6", places, ok)
+	}
+}
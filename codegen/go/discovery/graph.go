@@ -0,0 +1,357 @@
+package discovery
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GraphIssueKind classifies an integrity problem in the series/event graph
+type GraphIssueKind string
+
+const (
+	GraphOrphan             GraphIssueKind = "orphan"              // entity names a parent series that is not in the graph
+	GraphDanglingChild      GraphIssueKind = "dangling_child"      // series lists a child that is not in the graph
+	GraphAsymmetric         GraphIssueKind = "asymmetric"          // only one side of a parent/child link declares it
+	GraphConflictingParent  GraphIssueKind = "conflicting_parent"  // entity is claimed by, or names, more than one parent
+	GraphMismatchedChildren GraphIssueKind = "mismatched_children" // child_event_ids and relationships.event_ids differ
+	GraphCycle              GraphIssueKind = "cycle"               // following parents returns to the start
+)
+
+// GraphIssue is one integrity problem. Related is the other end of the
+// offending link, when there is one.
+type GraphIssue struct {
+	Kind    GraphIssueKind
+	Ref     EntityRef
+	Related *EntityRef
+	Message string
+}
+
+func (i GraphIssue) String() string {
+	return fmt.Sprintf("%s %s/%s/%s: %s", i.Kind, i.Ref.VenueID, i.Ref.Kind, i.Ref.EventID, i.Message)
+}
+
+// Graph resolves the parent/child links declared by series and event
+// metadata: ChildEventIDs and Relationships.EventIDs on series,
+// ParentSeriesID and Relationships.SeriesID on events and series. A link
+// declared by either side is followed as long as both ends exist; the
+// child's own declaration wins when the two sides disagree. Graphs are
+// immutable once built.
+type Graph struct {
+	nodes  map[EntityRef]*graphNode
+	issues []GraphIssue
+}
+
+type graphNode struct {
+	parent      *EntityRef
+	children    []EntityRef
+	instruments []string
+
+	ownParent *EntityRef  // parent named by the entity itself
+	claimedBy []EntityRef // series that list the entity as a child
+}
+
+// BuildGraph builds the relationship graph of the given metadata. Entities
+// are matched by venue and ID; a series child ID resolves to an event first
+// and to a series otherwise.
+func BuildGraph(series []SeriesMetadataV0, events []EventMetadataV0) *Graph {
+	g := &Graph{nodes: make(map[EntityRef]*graphNode)}
+	for _, s := range series {
+		g.nodes[EntityRef{VenueID: s.VenueID, Kind: DiscoveryKindSeries, EventID: s.EventID}] = &graphNode{}
+	}
+	for _, e := range events {
+		g.nodes[EntityRef{VenueID: e.VenueID, Kind: DiscoveryKindEvent, EventID: e.EventID}] = &graphNode{}
+	}
+
+	for _, s := range series {
+		ref := EntityRef{VenueID: s.VenueID, Kind: DiscoveryKindSeries, EventID: s.EventID}
+		node := g.nodes[ref]
+		var relSeries *string
+		var relEvents []string
+		if s.Relationships != nil {
+			relSeries, relEvents = s.Relationships.SeriesID, s.Relationships.EventIDs
+			node.instruments = s.Relationships.InstrumentIDs
+		}
+		g.declareParent(ref, node, nil, relSeries)
+		if len(s.ChildEventIDs) > 0 && len(relEvents) > 0 && !sameIDs(s.ChildEventIDs, relEvents) {
+			g.issue(GraphMismatchedChildren, ref, nil, "child_event_ids %v differ from relationships.event_ids %v", s.ChildEventIDs, relEvents)
+		}
+		for _, id := range unionIDs(s.ChildEventIDs, relEvents) {
+			child, ok := g.resolveChild(s.VenueID, id)
+			if !ok {
+				missing := EntityRef{VenueID: s.VenueID, Kind: DiscoveryKindEvent, EventID: id}
+				g.issue(GraphDanglingChild, ref, &missing, "child %s is not in the graph", id)
+				continue
+			}
+			g.nodes[child].claimedBy = append(g.nodes[child].claimedBy, ref)
+		}
+	}
+	for _, e := range events {
+		ref := EntityRef{VenueID: e.VenueID, Kind: DiscoveryKindEvent, EventID: e.EventID}
+		node := g.nodes[ref]
+		var relSeries *string
+		if e.Relationships != nil {
+			relSeries = e.Relationships.SeriesID
+			node.instruments = e.Relationships.InstrumentIDs
+		}
+		g.declareParent(ref, node, e.ParentSeriesID, relSeries)
+	}
+
+	for _, ref := range g.refs() {
+		g.resolveParent(ref, g.nodes[ref])
+	}
+	for _, ref := range g.refs() {
+		if parent := g.nodes[ref].parent; parent != nil {
+			g.nodes[*parent].children = append(g.nodes[*parent].children, ref)
+		}
+	}
+	g.findCycles()
+	return g
+}
+
+// declareParent records the parent an entity names for itself. Both fields
+// must agree when both are set.
+func (g *Graph) declareParent(ref EntityRef, node *graphNode, parentSeriesID, relSeriesID *string) {
+	id := parentSeriesID
+	if id == nil {
+		id = relSeriesID
+	} else if relSeriesID != nil && *relSeriesID != *id {
+		other := EntityRef{VenueID: ref.VenueID, Kind: DiscoveryKindSeries, EventID: *relSeriesID}
+		g.issue(GraphConflictingParent, ref, &other, "parent_series_id %s but relationships.series_id %s", *id, *relSeriesID)
+	}
+	if id == nil {
+		return
+	}
+	parent := EntityRef{VenueID: ref.VenueID, Kind: DiscoveryKindSeries, EventID: *id}
+	node.ownParent = &parent
+	if _, ok := g.nodes[parent]; !ok {
+		g.issue(GraphOrphan, ref, &parent, "parent series %s is not in the graph", *id)
+	}
+}
+
+func (g *Graph) resolveParent(ref EntityRef, node *graphNode) {
+	claimers := append([]EntityRef(nil), node.claimedBy...)
+	sort.Slice(claimers, func(i, j int) bool { return refLess(claimers[i], claimers[j]) })
+
+	if own := node.ownParent; own != nil {
+		if _, ok := g.nodes[*own]; !ok {
+			return
+		}
+		parent := *own
+		node.parent = &parent
+		listed := false
+		for _, c := range claimers {
+			if c == parent {
+				listed = true
+			} else {
+				claimer := c
+				g.issue(GraphConflictingParent, ref, &claimer, "names parent %s but is listed as a child of %s", parent.EventID, c.EventID)
+			}
+		}
+		if !listed {
+			g.issue(GraphAsymmetric, parent, &ref, "does not list child %s, which names it as parent", ref.EventID)
+		}
+		return
+	}
+
+	if len(claimers) == 0 {
+		return
+	}
+	parent := claimers[0]
+	node.parent = &parent
+	g.issue(GraphAsymmetric, ref, &parent, "listed as a child of %s but does not name a parent", parent.EventID)
+	for _, c := range claimers[1:] {
+		claimer := c
+		g.issue(GraphConflictingParent, ref, &claimer, "listed as a child of both %s and %s", parent.EventID, c.EventID)
+	}
+}
+
+// findCycles reports each parent cycle once, on its smallest member, and
+// cuts it there so traversals terminate
+func (g *Graph) findCycles() {
+	done := make(map[EntityRef]bool)
+	for _, start := range g.refs() {
+		path := make(map[EntityRef]bool)
+		var members []EntityRef
+		ref := start
+		for !done[ref] {
+			if path[ref] {
+				cycle := members[indexOf(members, ref):]
+				sort.Slice(cycle, func(i, j int) bool { return refLess(cycle[i], cycle[j]) })
+				head := cycle[0]
+				ids := make([]string, len(cycle))
+				for i, m := range cycle {
+					ids[i] = m.EventID
+				}
+				g.issue(GraphCycle, head, nil, "parent cycle through %v", ids)
+				g.cut(head)
+				break
+			}
+			path[ref] = true
+			members = append(members, ref)
+			parent := g.nodes[ref].parent
+			if parent == nil {
+				break
+			}
+			ref = *parent
+		}
+		for _, m := range members {
+			done[m] = true
+		}
+	}
+}
+
+func (g *Graph) cut(ref EntityRef) {
+	node := g.nodes[ref]
+	parent := g.nodes[*node.parent]
+	for i, c := range parent.children {
+		if c == ref {
+			parent.children = append(parent.children[:i:i], parent.children[i+1:]...)
+			break
+		}
+	}
+	node.parent = nil
+}
+
+func indexOf(refs []EntityRef, ref EntityRef) int {
+	for i, r := range refs {
+		if r == ref {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Graph) resolveChild(venue VenueID, id string) (EntityRef, bool) {
+	for _, kind := range []DiscoveryKind{DiscoveryKindEvent, DiscoveryKindSeries} {
+		ref := EntityRef{VenueID: venue, Kind: kind, EventID: id}
+		if _, ok := g.nodes[ref]; ok {
+			return ref, true
+		}
+	}
+	return EntityRef{}, false
+}
+
+func (g *Graph) issue(kind GraphIssueKind, ref EntityRef, related *EntityRef, format string, args ...any) {
+	g.issues = append(g.issues, GraphIssue{Kind: kind, Ref: ref, Related: related, Message: fmt.Sprintf(format, args...)})
+}
+
+func (g *Graph) refs() []EntityRef {
+	refs := make([]EntityRef, 0, len(g.nodes))
+	for ref := range g.nodes {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refLess(refs[i], refs[j]) })
+	return refs
+}
+
+// Issues returns every integrity problem found while building the graph
+func (g *Graph) Issues() []GraphIssue {
+	return append([]GraphIssue(nil), g.issues...)
+}
+
+// Contains reports whether an entity is in the graph
+func (g *Graph) Contains(ref EntityRef) bool {
+	_, ok := g.nodes[ref]
+	return ok
+}
+
+// Parent returns the resolved parent series of an entity
+func (g *Graph) Parent(ref EntityRef) (EntityRef, bool) {
+	node, ok := g.nodes[ref]
+	if !ok || node.parent == nil {
+		return EntityRef{}, false
+	}
+	return *node.parent, true
+}
+
+// Children returns the resolved children of a series, sorted
+func (g *Graph) Children(ref EntityRef) []EntityRef {
+	node, ok := g.nodes[ref]
+	if !ok {
+		return nil
+	}
+	return append([]EntityRef(nil), node.children...)
+}
+
+// Descendants returns every entity below ref, sorted
+func (g *Graph) Descendants(ref EntityRef) []EntityRef {
+	var out []EntityRef
+	g.walk(ref, func(r EntityRef) {
+		if r != ref {
+			out = append(out, r)
+		}
+	})
+	sort.Slice(out, func(i, j int) bool { return refLess(out[i], out[j]) })
+	return out
+}
+
+// Roots returns the entities without a resolved parent, sorted
+func (g *Graph) Roots() []EntityRef {
+	var out []EntityRef
+	for _, ref := range g.refs() {
+		if g.nodes[ref].parent == nil {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+// InstrumentsUnder returns the sorted, de-duplicated instrument IDs of ref
+// and all its descendants, e.g. every instrument under a series
+func (g *Graph) InstrumentsUnder(ref EntityRef) []string {
+	seen := make(map[string]bool)
+	var out []string
+	g.walk(ref, func(r EntityRef) {
+		for _, id := range g.nodes[r].instruments {
+			if !seen[id] {
+				seen[id] = true
+				out = append(out, id)
+			}
+		}
+	})
+	sort.Strings(out)
+	return out
+}
+
+func (g *Graph) walk(ref EntityRef, visit func(EntityRef)) {
+	if _, ok := g.nodes[ref]; !ok {
+		return
+	}
+	visit(ref)
+	for _, child := range g.nodes[ref].children {
+		g.walk(child, visit)
+	}
+}
+
+// Graph builds the relationship graph of the catalog's live entities
+func (c *Catalog) Graph() *Graph {
+	var series []SeriesMetadataV0
+	var events []EventMetadataV0
+	for _, entry := range c.Live("") {
+		if entry.Series != nil {
+			series = append(series, *entry.Series)
+		}
+		if entry.Event != nil {
+			events = append(events, *entry.Event)
+		}
+	}
+	return BuildGraph(series, events)
+}
+
+func unionIDs(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var out []string
+	for _, ids := range [][]string{a, b} {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				out = append(out, id)
+			}
+		}
+	}
+	return out
+}
+
+func sameIDs(a, b []string) bool {
+	return len(unionIDs(a, b)) == len(unionIDs(a, nil)) && len(unionIDs(a, nil)) == len(unionIDs(b, nil))
+}
@@ -0,0 +1,130 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+)
+
+func graphSeries(id string, children []string, rel *RelationshipsV0) SeriesMetadataV0 {
+	return SeriesMetadataV0{Kind: DiscoveryKindSeries, VenueID: VenueIDPolymarket, EventID: id, ChildEventIDs: children, Relationships: rel}
+}
+
+func graphEvent(id string, parent *string, rel *RelationshipsV0) EventMetadataV0 {
+	return EventMetadataV0{Kind: DiscoveryKindEvent, VenueID: VenueIDPolymarket, EventID: id, ParentSeriesID: parent, Relationships: rel}
+}
+
+func seriesRef(id string) EntityRef {
+	return EntityRef{VenueID: VenueIDPolymarket, Kind: DiscoveryKindSeries, EventID: id}
+}
+
+func eventRef(id string) EntityRef {
+	return EntityRef{VenueID: VenueIDPolymarket, Kind: DiscoveryKindEvent, EventID: id}
+}
+
+func issueKinds(g *Graph) map[GraphIssueKind][]string {
+	out := make(map[GraphIssueKind][]string)
+	for _, i := range g.Issues() {
+		out[i.Kind] = append(out[i.Kind], i.Ref.EventID)
+	}
+	return out
+}
+
+func TestGraph_ConsistentHierarchy(t *testing.T) {
+	// Same shape as TestRelationshipMapping, with every event present
+	series := graphSeries("series_123", []string{"event_1", "event_2"}, &RelationshipsV0{EventIDs: []string{"event_1", "event_2"}})
+	events := []EventMetadataV0{
+		graphEvent("event_1", stringPtr("series_123"), &RelationshipsV0{SeriesID: stringPtr("series_123"), InstrumentIDs: []string{"inst_1_yes", "inst_1_no"}}),
+		graphEvent("event_2", stringPtr("series_123"), &RelationshipsV0{InstrumentIDs: []string{"inst_2_yes", "inst_2_no"}}),
+	}
+	g := BuildGraph([]SeriesMetadataV0{series}, events)
+
+	if issues := g.Issues(); len(issues) != 0 {
+		t.Errorf("Issues() = %v, want none", issues)
+	}
+	if got := g.Children(seriesRef("series_123")); !reflect.DeepEqual(got, []EntityRef{eventRef("event_1"), eventRef("event_2")}) {
+		t.Errorf("Children() = %v", got)
+	}
+	if parent, ok := g.Parent(eventRef("event_2")); !ok || parent != seriesRef("series_123") {
+		t.Errorf("Parent() = %v, %v", parent, ok)
+	}
+	want := []string{"inst_1_no", "inst_1_yes", "inst_2_no", "inst_2_yes"}
+	if got := g.InstrumentsUnder(seriesRef("series_123")); !reflect.DeepEqual(got, want) {
+		t.Errorf("InstrumentsUnder() = %v, want %v", got, want)
+	}
+	if got := g.Roots(); !reflect.DeepEqual(got, []EntityRef{seriesRef("series_123")}) {
+		t.Errorf("Roots() = %v", got)
+	}
+}
+
+func TestGraph_IntegrityIssues(t *testing.T) {
+	series := []SeriesMetadataV0{
+		// event_3 is missing; child lists disagree
+		graphSeries("s1", []string{"event_1", "event_3"}, &RelationshipsV0{EventIDs: []string{"event_1"}}),
+		// Lists event_1 too, which already names s1
+		graphSeries("s2", []string{"event_1", "event_2"}, nil),
+		// Does not list event_4, which names it
+		graphSeries("s3", nil, nil),
+	}
+	events := []EventMetadataV0{
+		graphEvent("event_1", stringPtr("s1"), nil),
+		graphEvent("event_2", nil, nil),
+		graphEvent("event_4", stringPtr("s3"), nil),
+		graphEvent("event_5", stringPtr("gone"), nil),
+		graphEvent("event_6", stringPtr("s1"), &RelationshipsV0{SeriesID: stringPtr("s2")}),
+	}
+	g := BuildGraph(series, events)
+
+	got := issueKinds(g)
+	want := map[GraphIssueKind][]string{
+		GraphMismatchedChildren: {"s1"},
+		GraphDanglingChild:      {"s1"},
+		GraphOrphan:             {"event_5"},
+		GraphConflictingParent:  {"event_6", "event_1"},
+		GraphAsymmetric:         {"event_2", "s3", "s1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %v, want %v", got, want)
+	}
+
+	if parent, _ := g.Parent(eventRef("event_1")); parent != seriesRef("s1") {
+		t.Errorf("Parent(event_1) = %v, want the event's own declaration", parent)
+	}
+	if parent, _ := g.Parent(eventRef("event_2")); parent != seriesRef("s2") {
+		t.Errorf("Parent(event_2) = %v, want series that lists it", parent)
+	}
+	if _, ok := g.Parent(eventRef("event_5")); ok {
+		t.Error("orphan resolved to a parent")
+	}
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	series := []SeriesMetadataV0{
+		graphSeries("a", []string{"b"}, &RelationshipsV0{SeriesID: stringPtr("b")}),
+		graphSeries("b", []string{"a"}, &RelationshipsV0{SeriesID: stringPtr("a")}),
+		graphSeries("c", nil, &RelationshipsV0{SeriesID: stringPtr("c")}),
+	}
+	g := BuildGraph(series, []EventMetadataV0{graphEvent("e", stringPtr("a"), &RelationshipsV0{InstrumentIDs: []string{"i"}})})
+
+	if got := issueKinds(g)[GraphCycle]; !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("cycle issues on %v, want [a c]", got)
+	}
+	// The cycle is cut above its smallest member, so a becomes a root
+	if got := g.InstrumentsUnder(seriesRef("a")); !reflect.DeepEqual(got, []string{"i"}) {
+		t.Errorf("InstrumentsUnder(a) = %v", got)
+	}
+	if got := g.Descendants(seriesRef("a")); !reflect.DeepEqual(got, []EntityRef{eventRef("e"), seriesRef("b")}) {
+		t.Errorf("Descendants(a) = %v", got)
+	}
+}
+
+func TestCatalog_Graph(t *testing.T) {
+	c := NewCatalog()
+	p := catalogEvent("PRES-28", EventTypeDiscovered, catalogT0, "pres")
+	p.Event.ParentSeriesID = stringPtr("KXPRES")
+	c.ApplyEvent(p)
+
+	issues := c.Graph().Issues()
+	if len(issues) != 1 || issues[0].Kind != GraphOrphan {
+		t.Errorf("Graph().Issues() = %v, want one orphan", issues)
+	}
+}
@@ -0,0 +1,128 @@
+package discovery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity says whether a violation fails validation
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// RuleCode is a machine-readable identifier for a validation rule
+type RuleCode string
+
+const (
+	RuleRequired  RuleCode = "required"  // field missing, empty or zero
+	RuleConst     RuleCode = "const"     // field must hold a fixed value
+	RuleEnum      RuleCode = "enum"      // value outside the allowed set
+	RuleVenue     RuleCode = "venue"     // venue not in the venue registry
+	RuleMinimum   RuleCode = "minimum"   // number below its lower bound
+	RulePrecision RuleCode = "precision" // amount finer than its currency allows
+)
+
+// ValidationReport collects every violation found in one validation pass.
+// It is returned as the error of the Validate* functions when at least one
+// violation has SeverityError. errors.As works with both *ValidationReport
+// and ValidationError, the latter matching the first violation.
+type ValidationReport struct {
+	Violations []ValidationError
+}
+
+func (r *ValidationReport) Error() string {
+	errs := r.Errors()
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+	msgs := make([]string, len(errs))
+	for i, v := range errs {
+		msgs[i] = v.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap exposes each error-severity violation to errors.Is and errors.As
+func (r *ValidationReport) Unwrap() []error {
+	errs := r.Errors()
+	out := make([]error, len(errs))
+	for i, v := range errs {
+		out[i] = v
+	}
+	return out
+}
+
+// Errors returns the violations with SeverityError
+func (r *ValidationReport) Errors() []ValidationError {
+	return r.bySeverity(SeverityError)
+}
+
+// Warnings returns the violations with SeverityWarning
+func (r *ValidationReport) Warnings() []ValidationError {
+	return r.bySeverity(SeverityWarning)
+}
+
+// HasErrors reports whether any violation fails validation
+func (r *ValidationReport) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Err returns the report as an error if it has error-severity violations, or nil
+func (r *ValidationReport) Err() error {
+	if r.HasErrors() {
+		return r
+	}
+	return nil
+}
+
+func (r *ValidationReport) bySeverity(severity Severity) []ValidationError {
+	var out []ValidationError
+	for _, v := range r.Violations {
+		if v.Severity == severity {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (r *ValidationReport) add(path string, rule RuleCode, message string) {
+	r.addWithSeverity(path, rule, SeverityError, message)
+}
+
+func (r *ValidationReport) addWithSeverity(path string, rule RuleCode, severity Severity, message string) {
+	r.Violations = append(r.Violations, ValidationError{
+		Field:    lastToken(path),
+		Message:  message,
+		Path:     path,
+		Rule:     rule,
+		Severity: severity,
+	})
+}
+
+// pointer appends reference tokens to a JSON Pointer (RFC 6901)
+func pointer(base string, tokens ...any) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, token := range tokens {
+		b.WriteByte('/')
+		switch t := token.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		default:
+			s := fmt.Sprint(t)
+			s = strings.ReplaceAll(s, "~", "~0")
+			b.WriteString(strings.ReplaceAll(s, "/", "~1"))
+		}
+	}
+	return b.String()
+}
+
+func lastToken(path string) string {
+	token := path[strings.LastIndexByte(path, '/')+1:]
+	token = strings.ReplaceAll(token, "~1", "/")
+	return strings.ReplaceAll(token, "~0", "~")
+}
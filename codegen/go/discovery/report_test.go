@@ -0,0 +1,98 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidationReport_CollectsAllViolations(t *testing.T) {
+	payload := SeriesDiscoveryPayloadV0{
+		Event: SeriesMetadataV0{
+			Kind:         DiscoveryKindSeries,
+			VenueID:      VenueIDKalshi,
+			EventID:      "KXPRES",
+			Active:       boolPtr(true),
+			Closed:       boolPtr(false),
+			DiscoveredAt: time.Now(),
+			LastSeen:     time.Now(),
+			SeriesData: &SeriesDataV0{
+				Financial: &FinancialDataV0{Volume24hUSD: floatPtr(10.001)},
+				Contract: &ContractDataV0{SettlementSources: []SettlementSourceV0{
+					{Name: "AP"}, {Name: "Reuters"}, {Name: ""},
+				}},
+			},
+		},
+		EventID:   "KXPRES",
+		EventType: "renamed",
+		Timestamp: time.Now(),
+		VenueID:   VenueIDKalshi,
+		DiscoveryMeta: &DiscoveryMetaV0{
+			BatchID: "b", BatchSequence: 0, BatchTotalCount: 1, DiscoveryRunID: "r",
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidateSeriesDiscoveryPayload(data)
+	var report *ValidationReport
+	if !errors.As(err, &report) {
+		t.Fatalf("ValidateSeriesDiscoveryPayload() error = %v, want *ValidationReport", err)
+	}
+
+	type got struct {
+		Path string
+		Rule RuleCode
+	}
+	var violations []got
+	for _, v := range report.Violations {
+		if v.Severity != SeverityError {
+			t.Errorf("%s: severity = %q, want error", v.Path, v.Severity)
+		}
+		violations = append(violations, got{v.Path, v.Rule})
+	}
+	want := []got{
+		{"/event/title", RuleRequired},
+		{"/event/series_data/financial/volume_24h_usd", RulePrecision},
+		{"/event/series_data/contract/settlement_sources/2/name", RuleRequired},
+		{"/event_type", RuleEnum},
+		{"/discovery_meta/batch_sequence", RuleMinimum},
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("violations = %v, want %v", violations, want)
+	}
+
+	var first ValidationError
+	if !errors.As(err, &first) || first.Field != "title" || first.Path != "/event/title" {
+		t.Errorf("errors.As(ValidationError) = %+v", first)
+	}
+}
+
+func TestValidationReport_Severity(t *testing.T) {
+	r := &ValidationReport{}
+	r.addWithSeverity("/event/description", RuleRequired, SeverityWarning, "recommended field is empty")
+	if r.HasErrors() || r.Err() != nil || len(r.Warnings()) != 1 {
+		t.Errorf("warning-only report: HasErrors() = %v, Err() = %v", r.HasErrors(), r.Err())
+	}
+
+	r.add("/event/title", RuleRequired, "required field is empty")
+	if r.Err() == nil || len(r.Errors()) != 1 {
+		t.Errorf("Err() = %v, Errors() = %v", r.Err(), r.Errors())
+	}
+	if got := r.Error(); got != "validation error at '/event/title': required field is empty" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestPointer(t *testing.T) {
+	if got := pointer("/event", "extra_metadata", "a/b~c", 0); got != "/event/extra_metadata/a~1b~0c/0" {
+		t.Errorf("pointer() = %q", got)
+	}
+	if got := lastToken("/event/extra_metadata/a~1b~0c"); got != "a/b~c" {
+		t.Errorf("lastToken() = %q", got)
+	}
+}
@@ -24,6 +24,9 @@ type FinancialDataV0 struct {
 	Volume24hUSD         *float64 `json:"volume_24h_usd,omitempty"`
 	VolumeTotalUSD       *float64 `json:"volume_total_usd,omitempty"`
 	LiquidityTotalUSD    *float64 `json:"liquidity_total_usd,omitempty"`
+	Volume24hNative      *float64 `json:"volume_24h_native,omitempty"`
+	VolumeTotalNative    *float64 `json:"volume_total_native,omitempty"`
+	LiquidityTotalNative *float64 `json:"liquidity_total_native,omitempty"`
 	Volume24hContracts   *int     `json:"volume_24h_contracts,omitempty"`
 	VolumeTotalContracts *int     `json:"volume_total_contracts,omitempty"`
 	Score                *float64 `json:"score,omitempty"`
@@ -5,198 +5,218 @@ package discovery
 import (
 	"encoding/json"
 	"fmt"
-	"math"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/money"
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
 )
 
-// ValidationError represents a validation error with context
+// ValidationError represents a validation error with context. Path is the
+// JSON Pointer of the field within the validated document, and Field its last
+// token.
 type ValidationError struct {
-	Field   string
-	Message string
+	Field    string
+	Message  string
+	Path     string
+	Rule     RuleCode
+	Severity Severity
 }
 
 func (e ValidationError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("validation error at '%s': %s", e.Path, e.Message)
+	}
 	return fmt.Sprintf("validation error in field '%s': %s", e.Field, e.Message)
 }
 
-// ValidateEventDiscoveryPayload validates an event discovery payload
+// ValidateEventDiscoveryPayload validates an event discovery payload field by
+// field. Every violation is reported in a *ValidationReport; use a Validator
+// to also apply cross-field rules.
 func ValidateEventDiscoveryPayload(payload []byte) error {
-	var p EventDiscoveryPayloadV0
-	if err := json.Unmarshal(payload, &p); err != nil {
-		return fmt.Errorf("failed to unmarshal payload: %w", err)
-	}
-
-	if err := validateEventMetadata(p.Event); err != nil {
-		return fmt.Errorf("event metadata validation failed: %w", err)
-	}
-
-	if p.EventID == "" {
-		return ValidationError{Field: "event_id", Message: "required field is empty"}
-	}
+	return FieldOnly().ValidateEventDiscoveryPayload(payload)
+}
 
-	if !isValidEventType(p.EventType) {
-		return ValidationError{Field: "event_type", Message: "invalid event type"}
-	}
+// CheckEventDiscoveryPayload returns every field violation in an event
+// discovery payload, including warnings
+func CheckEventDiscoveryPayload(p EventDiscoveryPayloadV0) *ValidationReport {
+	return checkEventDiscoveryFields(p)
+}
 
-	if p.Timestamp.IsZero() {
-		return ValidationError{Field: "timestamp", Message: "required field is zero"}
-	}
+func checkEventDiscoveryFields(p EventDiscoveryPayloadV0) *ValidationReport {
+	r := &ValidationReport{}
+	checkEventMetadata(r, "/event", p.Event)
+	checkPayloadFields(r, p.EventID, p.EventType, p.Timestamp.IsZero(), p.VenueID, p.DiscoveryMeta)
+	return r
+}
 
-	if !isValidVenueID(p.VenueID) {
-		return ValidationError{Field: "venue_id", Message: "invalid venue ID"}
-	}
+// ValidateSeriesDiscoveryPayload validates a series discovery payload field by
+// field. Every violation is reported in a *ValidationReport; use a Validator
+// to also apply cross-field rules.
+func ValidateSeriesDiscoveryPayload(payload []byte) error {
+	return FieldOnly().ValidateSeriesDiscoveryPayload(payload)
+}
 
-	if p.DiscoveryMeta != nil {
-		if err := validateDiscoveryMeta(*p.DiscoveryMeta); err != nil {
-			return fmt.Errorf("discovery_meta validation failed: %w", err)
-		}
-	}
+// CheckSeriesDiscoveryPayload returns every field violation in a series
+// discovery payload, including warnings
+func CheckSeriesDiscoveryPayload(p SeriesDiscoveryPayloadV0) *ValidationReport {
+	return checkSeriesDiscoveryFields(p)
+}
 
-	return nil
+func checkSeriesDiscoveryFields(p SeriesDiscoveryPayloadV0) *ValidationReport {
+	r := &ValidationReport{}
+	checkSeriesMetadata(r, "/event", p.Event)
+	checkPayloadFields(r, p.EventID, p.EventType, p.Timestamp.IsZero(), p.VenueID, p.DiscoveryMeta)
+	return r
 }
 
-// ValidateSeriesDiscoveryPayload validates a series discovery payload
-func ValidateSeriesDiscoveryPayload(payload []byte) error {
-	var p SeriesDiscoveryPayloadV0
+func unmarshalPayload[T any](payload []byte) (T, error) {
+	var p T
 	if err := json.Unmarshal(payload, &p); err != nil {
-		return fmt.Errorf("failed to unmarshal payload: %w", err)
-	}
-
-	if err := validateSeriesMetadata(p.Event); err != nil {
-		return fmt.Errorf("series metadata validation failed: %w", err)
+		return p, fmt.Errorf("failed to unmarshal payload: %w", err)
 	}
+	return p, nil
+}
 
-	if p.EventID == "" {
-		return ValidationError{Field: "event_id", Message: "required field is empty"}
+func checkPayloadFields(r *ValidationReport, eventID string, eventType EventType, zeroTimestamp bool, venueID VenueID, meta *DiscoveryMetaV0) {
+	if eventID == "" {
+		r.add("/event_id", RuleRequired, "required field is empty")
 	}
 
-	if !isValidEventType(p.EventType) {
-		return ValidationError{Field: "event_type", Message: "invalid event type"}
+	if !isValidEventType(eventType) {
+		r.add("/event_type", RuleEnum, "invalid event type")
 	}
 
-	if p.Timestamp.IsZero() {
-		return ValidationError{Field: "timestamp", Message: "required field is zero"}
+	if zeroTimestamp {
+		r.add("/timestamp", RuleRequired, "required field is zero")
 	}
 
-	if !isValidVenueID(p.VenueID) {
-		return ValidationError{Field: "venue_id", Message: "invalid venue ID"}
+	if !isValidVenueID(venueID) {
+		r.add("/venue_id", RuleVenue, "invalid venue ID")
 	}
 
-	if p.DiscoveryMeta != nil {
-		if err := validateDiscoveryMeta(*p.DiscoveryMeta); err != nil {
-			return fmt.Errorf("discovery_meta validation failed: %w", err)
-		}
+	if meta != nil {
+		checkDiscoveryMeta(r, "/discovery_meta", *meta)
 	}
-
-	return nil
 }
 
 // ValidateEventMetadata validates event metadata
 func ValidateEventMetadata(metadata EventMetadataV0) error {
-	return validateEventMetadata(metadata)
+	return CheckEventMetadata(metadata).Err()
+}
+
+// CheckEventMetadata returns every violation in event metadata, including warnings
+func CheckEventMetadata(metadata EventMetadataV0) *ValidationReport {
+	r := &ValidationReport{}
+	checkEventMetadata(r, "", metadata)
+	return r
 }
 
 // ValidateSeriesMetadata validates series metadata
 func ValidateSeriesMetadata(metadata SeriesMetadataV0) error {
-	return validateSeriesMetadata(metadata)
+	return CheckSeriesMetadata(metadata).Err()
+}
+
+// CheckSeriesMetadata returns every violation in series metadata, including warnings
+func CheckSeriesMetadata(metadata SeriesMetadataV0) *ValidationReport {
+	r := &ValidationReport{}
+	checkSeriesMetadata(r, "", metadata)
+	return r
 }
 
-func validateEventMetadata(metadata EventMetadataV0) error {
+func checkEventMetadata(r *ValidationReport, path string, metadata EventMetadataV0) {
 	if metadata.Kind != DiscoveryKindEvent {
-		return ValidationError{Field: "kind", Message: "must be 'event' for event metadata"}
+		r.add(pointer(path, "kind"), RuleConst, "must be 'event' for event metadata")
 	}
 
 	if !isValidVenueID(metadata.VenueID) {
-		return ValidationError{Field: "venue_id", Message: "invalid venue ID"}
+		r.add(pointer(path, "venue_id"), RuleVenue, "invalid venue ID")
 	}
 
 	if metadata.EventID == "" {
-		return ValidationError{Field: "event_id", Message: "required field is empty"}
+		r.add(pointer(path, "event_id"), RuleRequired, "required field is empty")
 	}
 
 	if metadata.Title == "" {
-		return ValidationError{Field: "title", Message: "required field is empty"}
+		r.add(pointer(path, "title"), RuleRequired, "required field is empty")
 	}
 
 	if metadata.Active == nil {
-		return ValidationError{Field: "active", Message: "required field is missing"}
+		r.add(pointer(path, "active"), RuleRequired, "required field is missing")
 	}
 
 	if metadata.Closed == nil {
-		return ValidationError{Field: "closed", Message: "required field is missing"}
+		r.add(pointer(path, "closed"), RuleRequired, "required field is missing")
 	}
 
 	if metadata.DiscoveredAt.IsZero() {
-		return ValidationError{Field: "discovered_at", Message: "required field is zero"}
+		r.add(pointer(path, "discovered_at"), RuleRequired, "required field is zero")
 	}
 
 	if metadata.LastSeen.IsZero() {
-		return ValidationError{Field: "last_seen", Message: "required field is zero"}
+		r.add(pointer(path, "last_seen"), RuleRequired, "required field is zero")
 	}
-
-	return nil
 }
 
-func validateSeriesMetadata(metadata SeriesMetadataV0) error {
+func checkSeriesMetadata(r *ValidationReport, path string, metadata SeriesMetadataV0) {
 	if metadata.Kind != DiscoveryKindSeries {
-		return ValidationError{Field: "kind", Message: "must be 'series' for series metadata"}
+		r.add(pointer(path, "kind"), RuleConst, "must be 'series' for series metadata")
 	}
 
 	if !isValidVenueID(metadata.VenueID) {
-		return ValidationError{Field: "venue_id", Message: "invalid venue ID"}
+		r.add(pointer(path, "venue_id"), RuleVenue, "invalid venue ID")
 	}
 
 	if metadata.EventID == "" {
-		return ValidationError{Field: "event_id", Message: "required field is empty"}
+		r.add(pointer(path, "event_id"), RuleRequired, "required field is empty")
 	}
 
 	if metadata.Title == "" {
-		return ValidationError{Field: "title", Message: "required field is empty"}
+		r.add(pointer(path, "title"), RuleRequired, "required field is empty")
 	}
 
 	if metadata.Active == nil {
-		return ValidationError{Field: "active", Message: "required field is missing"}
+		r.add(pointer(path, "active"), RuleRequired, "required field is missing")
 	}
 
 	if metadata.Closed == nil {
-		return ValidationError{Field: "closed", Message: "required field is missing"}
+		r.add(pointer(path, "closed"), RuleRequired, "required field is missing")
 	}
 
 	if metadata.DiscoveredAt.IsZero() {
-		return ValidationError{Field: "discovered_at", Message: "required field is zero"}
+		r.add(pointer(path, "discovered_at"), RuleRequired, "required field is zero")
 	}
 
 	if metadata.LastSeen.IsZero() {
-		return ValidationError{Field: "last_seen", Message: "required field is zero"}
+		r.add(pointer(path, "last_seen"), RuleRequired, "required field is zero")
 	}
 
 	// Validate nested structures if present
 	if metadata.SeriesData != nil {
-		if err := validateSeriesData(*metadata.SeriesData); err != nil {
-			return fmt.Errorf("series_data validation failed: %w", err)
-		}
+		checkSeriesData(r, pointer(path, "series_data"), *metadata.SeriesData)
 	}
-
-	return nil
 }
 
 func validateDiscoveryMeta(meta DiscoveryMetaV0) error {
+	r := &ValidationReport{}
+	checkDiscoveryMeta(r, "", meta)
+	return r.Err()
+}
+
+func checkDiscoveryMeta(r *ValidationReport, path string, meta DiscoveryMetaV0) {
 	if meta.BatchID == "" {
-		return ValidationError{Field: "batch_id", Message: "required field is empty"}
+		r.add(pointer(path, "batch_id"), RuleRequired, "required field is empty")
 	}
 
 	if meta.BatchSequence < 1 {
-		return ValidationError{Field: "batch_sequence", Message: "must be >= 1"}
+		r.add(pointer(path, "batch_sequence"), RuleMinimum, "must be >= 1")
 	}
 
 	if meta.BatchTotalCount < 1 {
-		return ValidationError{Field: "batch_total_count", Message: "must be >= 1"}
+		r.add(pointer(path, "batch_total_count"), RuleMinimum, "must be >= 1")
 	}
 
 	if meta.DiscoveryRunID == "" {
-		return ValidationError{Field: "discovery_run_id", Message: "required field is empty"}
+		r.add(pointer(path, "discovery_run_id"), RuleRequired, "required field is empty")
 	}
-
-	return nil
 }
 
 func isValidEventType(eventType EventType) bool {
@@ -209,105 +229,106 @@ func isValidEventType(eventType EventType) bool {
 }
 
 func isValidVenueID(venueID VenueID) bool {
-	switch venueID {
-	case VenueIDPolymarket, VenueIDKalshi:
-		return true
-	default:
-		return false
-	}
+	return venues.IsValid(string(venueID))
 }
 
 // ValidateFinancialData validates financial data constraints
 func ValidateFinancialData(data FinancialDataV0) error {
-	if data.Volume24hUSD != nil {
-		if *data.Volume24hUSD < 0 {
-			return ValidationError{Field: "volume_24h_usd", Message: "must be >= 0"}
-		}
-		if !isValidCentsPrecision(*data.Volume24hUSD) {
-			return ValidationError{Field: "volume_24h_usd", Message: "must be a multiple of 0.01"}
-		}
-	}
-
-	if data.VolumeTotalUSD != nil {
-		if *data.VolumeTotalUSD < 0 {
-			return ValidationError{Field: "volume_total_usd", Message: "must be >= 0"}
-		}
-		if !isValidCentsPrecision(*data.VolumeTotalUSD) {
-			return ValidationError{Field: "volume_total_usd", Message: "must be a multiple of 0.01"}
-		}
-	}
+	r := &ValidationReport{}
+	checkFinancialData(r, "", data)
+	return r.Err()
+}
 
-	if data.LiquidityTotalUSD != nil {
-		if *data.LiquidityTotalUSD < 0 {
-			return ValidationError{Field: "liquidity_total_usd", Message: "must be >= 0"}
-		}
-		if !isValidCentsPrecision(*data.LiquidityTotalUSD) {
-			return ValidationError{Field: "liquidity_total_usd", Message: "must be a multiple of 0.01"}
+func checkFinancialData(r *ValidationReport, path string, data FinancialDataV0) {
+	currency, known := money.CurrencyUSD, true
+	if data.Currency != nil {
+		currency = money.Currency(*data.Currency)
+		if known = currency.IsValid(); !known {
+			r.add(pointer(path, "currency"), RuleEnum, fmt.Sprintf("%q is not a registered currency", *data.Currency))
 		}
 	}
+	checkAmount(r, pointer(path, "volume_24h_usd"), data.Volume24hUSD, money.CurrencyUSD, true)
+	checkAmount(r, pointer(path, "volume_total_usd"), data.VolumeTotalUSD, money.CurrencyUSD, true)
+	checkAmount(r, pointer(path, "liquidity_total_usd"), data.LiquidityTotalUSD, money.CurrencyUSD, true)
+	checkAmount(r, pointer(path, "volume_24h_native"), data.Volume24hNative, currency, known)
+	checkAmount(r, pointer(path, "volume_total_native"), data.VolumeTotalNative, currency, known)
+	checkAmount(r, pointer(path, "liquidity_total_native"), data.LiquidityTotalNative, currency, known)
 
 	if data.Volume24hContracts != nil && *data.Volume24hContracts < 0 {
-		return ValidationError{Field: "volume_24h_contracts", Message: "must be >= 0"}
+		r.add(pointer(path, "volume_24h_contracts"), RuleMinimum, "must be >= 0")
 	}
 
 	if data.VolumeTotalContracts != nil && *data.VolumeTotalContracts < 0 {
-		return ValidationError{Field: "volume_total_contracts", Message: "must be >= 0"}
+		r.add(pointer(path, "volume_total_contracts"), RuleMinimum, "must be >= 0")
 	}
 
 	if data.Score != nil && *data.Score < 0 {
-		return ValidationError{Field: "score", Message: "must be >= 0"}
+		r.add(pointer(path, "score"), RuleMinimum, "must be >= 0")
 	}
+}
 
-	if data.Currency != nil && *data.Currency != string(CurrencyUSD) {
-		return ValidationError{Field: "currency", Message: "must be 'USD'"}
+// checkAmount validates a monetary value in currency. Precision follows the
+// currency's minor units and is only checked when the currency is registered.
+func checkAmount(r *ValidationReport, path string, value *float64, currency money.Currency, known bool) {
+	if value == nil {
+		return
+	}
+	if *value < 0 {
+		r.add(path, RuleMinimum, "must be >= 0")
+	}
+	if !known {
+		return
+	}
+	if !isValidPrecision(*value, currency) {
+		info, _ := money.LookupCurrency(currency)
+		r.add(path, RulePrecision, fmt.Sprintf("must be a multiple of %s", info.Quantum()))
 	}
-
-	return nil
 }
 
 // ValidateSettlementSource validates settlement source data
 func ValidateSettlementSource(source SettlementSourceV0) error {
+	r := &ValidationReport{}
+	checkSettlementSource(r, "", source)
+	return r.Err()
+}
+
+func checkSettlementSource(r *ValidationReport, path string, source SettlementSourceV0) {
 	if source.Name == "" {
-		return ValidationError{Field: "name", Message: "required field is empty"}
+		r.add(pointer(path, "name"), RuleRequired, "required field is empty")
 	}
-
-	return nil
 }
 
-// validateSeriesData validates series-specific data structures
-func validateSeriesData(data SeriesDataV0) error {
-	// Validate financial data if present
+// checkSeriesData validates series-specific data structures
+func checkSeriesData(r *ValidationReport, path string, data SeriesDataV0) {
 	if data.Financial != nil {
-		if err := ValidateFinancialData(*data.Financial); err != nil {
-			return fmt.Errorf("financial data validation failed: %w", err)
-		}
+		checkFinancialData(r, pointer(path, "financial"), *data.Financial)
 	}
 
-	// Validate contract data if present
 	if data.Contract != nil {
-		if err := validateContractData(*data.Contract); err != nil {
-			return fmt.Errorf("contract data validation failed: %w", err)
-		}
+		checkContractData(r, pointer(path, "contract"), *data.Contract)
 	}
-
-	return nil
 }
 
-// validateContractData validates contract data structures
-func validateContractData(data ContractDataV0) error {
-	// Validate settlement sources
+// checkContractData validates contract data structures
+func checkContractData(r *ValidationReport, path string, data ContractDataV0) {
 	for i, source := range data.SettlementSources {
-		if err := ValidateSettlementSource(source); err != nil {
-			return fmt.Errorf("settlement_sources[%d] validation failed: %w", i, err)
-		}
+		checkSettlementSource(r, pointer(path, "settlement_sources", i), source)
 	}
-
-	return nil
 }
 
-// isValidCentsPrecision checks if a value is a multiple of 0.01 (cents precision)
+// isValidCentsPrecision checks if a value is a multiple of 0.01 (cents
+// precision), exactly: the value is read as the decimal JSON number it encodes
 func isValidCentsPrecision(value float64) bool {
-	// Round to cents and compare with original
-	rounded := math.Round(value*100) / 100
-	return math.Abs(value-rounded) < 1e-10 // Use small epsilon for floating point comparison
-}
\ No newline at end of file
+	return isValidPrecision(value, money.CurrencyUSD)
+}
+
+// isValidPrecision checks that value has no more decimal places than
+// currency's minor units allow
+func isValidPrecision(value float64, currency money.Currency) bool {
+	amount, err := money.FromFloat(value)
+	if err != nil {
+		return false
+	}
+	_, err = money.NewMoney(amount, currency)
+	return err == nil
+}
@@ -2,10 +2,13 @@ package discovery
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
 )
 
 func TestValidateEventDiscoveryPayload_Valid(t *testing.T) {
@@ -225,11 +228,36 @@ func TestValidateFinancialData(t *testing.T) {
 
 	// Test invalid currency
 	invalidCurrency := validData
-	invalidCurrency.Currency = stringPtr("EUR")
+	invalidCurrency.Currency = stringPtr("XYZ")
 	if err := ValidateFinancialData(invalidCurrency); err == nil {
 		t.Error("ValidateFinancialData() expected error for invalid currency, got nil")
 	}
 
+	// Test registered non-USD currencies: native precision follows the
+	// currency, *_usd stays at cents
+	euro := validData
+	euro.Currency = stringPtr("EUR")
+	euro.Volume24hNative = floatPtr(925.40)
+	if err := ValidateFinancialData(euro); err != nil {
+		t.Errorf("ValidateFinancialData() error = %v for EUR, want nil", err)
+	}
+	usdc := validData
+	usdc.Currency = stringPtr("USDC")
+	usdc.Volume24hNative = floatPtr(1000.123456)
+	if err := ValidateFinancialData(usdc); err != nil {
+		t.Errorf("ValidateFinancialData() error = %v for 6-place USDC, want nil", err)
+	}
+	usdc.Volume24hNative = floatPtr(1000.1234567)
+	var verr ValidationError
+	if err := ValidateFinancialData(usdc); !errors.As(err, &verr) || verr.Rule != RulePrecision || verr.Message != "must be a multiple of 0.000001" {
+		t.Errorf("ValidateFinancialData() error = %v for 7-place USDC, want a precision violation", err)
+	}
+	usdc.Volume24hNative = floatPtr(1000.123456)
+	usdc.Volume24hUSD = floatPtr(1000.123456)
+	if err := ValidateFinancialData(usdc); !errors.As(err, &verr) || verr.Path != "/volume_24h_usd" || verr.Message != "must be a multiple of 0.01" {
+		t.Errorf("ValidateFinancialData() error = %v for sub-cent *_usd in USDC data, want a precision violation", err)
+	}
+
 	// Test multipleOf validation - values that are not multiples of 0.01
 	invalidPrecision := validData
 	invalidPrecision.Volume24hUSD = floatPtr(123.456) // 3 decimal places
@@ -313,6 +341,20 @@ func TestIsValidVenueID(t *testing.T) {
 	}
 }
 
+func TestIsValidVenueID_RuntimeRegistered(t *testing.T) {
+	if isValidVenueID("manifold") {
+		t.Fatal("isValidVenueID('manifold') = true before registration")
+	}
+	err := venues.Register(venues.Descriptor{ID: "manifold", PriceUnit: venues.PriceUnitProbability, ContractSize: 1})
+	if err != nil {
+		t.Fatalf("venues.Register() error = %v", err)
+	}
+	t.Cleanup(func() { venues.Unregister("manifold") })
+	if !isValidVenueID("manifold") {
+		t.Error("isValidVenueID('manifold') = false after registration, want true")
+	}
+}
+
 // Helper functions
 func loadTestData(t *testing.T, filename string) []byte {
 	// Go up from codegen/go/discovery to find schemas/examples/discovery
@@ -566,6 +608,7 @@ func TestIsValidCentsPrecision(t *testing.T) {
 		{"Invalid half cent", 0.005, false},
 		{"Invalid tiny fraction", 100.001, false},
 		{"Invalid many decimals", 1.23456789, false},
+		{"Valid large amount", 1234567.89, true},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,161 @@
+package sundayschemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/discovery"
+)
+
+func discoveryExample(t *testing.T, name string) []byte {
+	t.Helper()
+	return loadExample(t, filepath.Join("..", "..", "schemas", "examples", "discovery", name))
+}
+
+// sameJSON reports whether a and b encode to the same JSON document
+func sameJSON(t *testing.T, a, b any) bool {
+	t.Helper()
+	var docs [2]any
+	for i, v := range []any{a, b} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &docs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return reflect.DeepEqual(docs[0], docs[1])
+}
+
+func TestDiscoveryConversion_EventRoundTrip(t *testing.T) {
+	for _, name := range []string{"event-payload-kalshi-valid.json", "event-payload-polymarket-valid.json", "minimal-event-payload.json"} {
+		t.Run(name, func(t *testing.T) {
+			data := discoveryExample(t, name)
+			var generated EventDiscoveryPayloadV0
+			var typed discovery.EventDiscoveryPayloadV0
+			if err := json.Unmarshal(data, &generated); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, &typed); err != nil {
+				t.Fatal(err)
+			}
+
+			converted, err := generated.ToDiscovery()
+			if err != nil {
+				t.Fatalf("ToDiscovery() error = %v", err)
+			}
+			if !reflect.DeepEqual(converted, typed) {
+				t.Errorf("ToDiscovery() = %+v, want %+v", converted, typed)
+			}
+			back, err := FromDiscoveryEventPayload(converted)
+			if err != nil {
+				t.Fatalf("FromDiscoveryEventPayload() error = %v", err)
+			}
+			if !reflect.DeepEqual(back, generated) {
+				t.Errorf("round trip = %+v, want %+v", back, generated)
+			}
+		})
+	}
+}
+
+func TestDiscoveryConversion_SeriesRoundTrip(t *testing.T) {
+	for _, name := range []string{"series-payload-kalshi-valid.json", "series-payload-polymarket-valid.json", "minimal-series-payload.json"} {
+		t.Run(name, func(t *testing.T) {
+			data := discoveryExample(t, name)
+			var generated SeriesDiscoveryPayloadV0
+			var typed discovery.SeriesDiscoveryPayloadV0
+			if err := json.Unmarshal(data, &generated); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, &typed); err != nil {
+				t.Fatal(err)
+			}
+
+			converted, err := generated.ToDiscovery()
+			if err != nil {
+				t.Fatalf("ToDiscovery() error = %v", err)
+			}
+			if !reflect.DeepEqual(converted, typed) {
+				t.Errorf("ToDiscovery() = %+v, want %+v", converted, typed)
+			}
+			back, err := FromDiscoverySeriesPayload(converted)
+			if err != nil {
+				t.Fatalf("FromDiscoverySeriesPayload() error = %v", err)
+			}
+			if !reflect.DeepEqual(back, generated) {
+				t.Errorf("round trip = %+v, want %+v", back, generated)
+			}
+			if !sameJSON(t, back, typed) {
+				t.Error("generated and discovery payloads encode differently")
+			}
+		})
+	}
+}
+
+func TestDiscoveryConversion_Errors(t *testing.T) {
+	var p discovery.EventDiscoveryPayloadV0
+	if err := json.Unmarshal(discoveryExample(t, "event-payload-kalshi-valid.json"), &p); err != nil {
+		t.Fatal(err)
+	}
+	p.Event.Closed = nil
+
+	_, err := FromDiscoveryEventPayload(p)
+	var conv ConversionError
+	if !errors.As(err, &conv) || conv.Field != "/event/closed" {
+		t.Errorf("FromDiscoveryEventPayload() error = %v, want ConversionError at /event/closed", err)
+	}
+}
+
+func rawDiscovery(t *testing.T, schema, stream string, payload []byte) []byte {
+	t.Helper()
+	return []byte(fmt.Sprintf(`{"envelope": {"venue_id": "kalshi", "stream": %q, "schema": %q, "timestamp": "2025-09-17T14:30:00Z"}, "payload": %s}`, stream, schema, payload))
+}
+
+func TestRawDiscovery_DiscoveryPayload(t *testing.T) {
+	raw, err := UnmarshalRawEventsDiscoveryV0(rawDiscovery(t, "raw.events.v0", "event_discovery", discoveryExample(t, "event-payload-kalshi-valid.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := raw.DiscoveryPayload()
+	if err != nil {
+		t.Fatalf("RawEventsDiscoveryV0.DiscoveryPayload() error = %v", err)
+	}
+	if event.Event.VenueID != discovery.VenueIDKalshi || event.Event.Active == nil {
+		t.Errorf("DiscoveryPayload() = %+v", event)
+	}
+
+	series, err := UnmarshalRawSeriesDiscoveryV0(rawDiscovery(t, "raw.series.v0", "series_discovery", discoveryExample(t, "series-payload-kalshi-valid.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := series.DiscoveryPayload(); err != nil {
+		t.Errorf("RawSeriesDiscoveryV0.DiscoveryPayload() error = %v", err)
+	}
+
+	// The published raw.events.v0 examples carry native venue payloads
+	v, _, err := Decode(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "raw.events.kalshi.example.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = v.(RawEventsDiscoveryV0).DiscoveryPayload()
+	var report *discovery.ValidationReport
+	if !errors.As(err, &report) || !report.HasErrors() {
+		t.Errorf("DiscoveryPayload() error = %v, want a validation report", err)
+	}
+}
+
+func TestRawCategories_VenueCategory(t *testing.T) {
+	v, _, err := Decode(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "raw.categories.polymarket.example.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	category, err := v.(RawCategoriesDiscoveryV0).VenueCategory()
+	if err != nil || category.VenueID != discovery.VenueIDPolymarket || category.Slug == nil || *category.Slug != "politics" {
+		t.Errorf("VenueCategory() = %+v, %v", category, err)
+	}
+}
@@ -0,0 +1,181 @@
+// Package sundayschemas provides a builder for raw.v0 envelopes
+package sundayschemas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock supplies the ingest time stamped on envelopes
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface
+type ClockFunc func() time.Time
+
+// Now calls f()
+func (f ClockFunc) Now() time.Time { return f() }
+
+// SystemClock reads the wall clock
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a clock that always reports t
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// PartitionKeyFunc derives the Kafka partition key for an envelope. It is
+// called once every other field, including the payload, has been set.
+type PartitionKeyFunc func(env *RawEnvelopeV0) (string, error)
+
+// PartitionByInstrument keys envelopes by venue and native instrument
+// ("kalshi:PRES-28"). This is the default and matches NewRawEnvelope.
+func PartitionByInstrument(env *RawEnvelopeV0) (string, error) {
+	return string(env.VenueID) + ":" + env.InstrumentNative, nil
+}
+
+// PartitionByVenue keys envelopes by venue only, so each venue's stream is totally ordered
+func PartitionByVenue(env *RawEnvelopeV0) (string, error) {
+	return string(env.VenueID), nil
+}
+
+// PartitionByPayloadField keys envelopes by venue and the first of the given
+// payload fields that is present, e.g. PartitionByPayloadField("event_ticker")
+// to keep every market of a Kalshi event on one partition, or
+// PartitionByPayloadField("series_ticker") to partition by series.
+func PartitionByPayloadField(fields ...string) PartitionKeyFunc {
+	return func(env *RawEnvelopeV0) (string, error) {
+		for _, field := range fields {
+			switch v := env.Payload[field].(type) {
+			case string:
+				if v != "" {
+					return string(env.VenueID) + ":" + v, nil
+				}
+			case float64:
+				return string(env.VenueID) + ":" + strconv.FormatFloat(v, 'f', -1, 64), nil
+			}
+		}
+		return "", fmt.Errorf("payload has none of the partition fields %s", strings.Join(fields, ", "))
+	}
+}
+
+// RawEnvelopeBuilder builds validated RawEnvelopeV0 values. Builders are
+// immutable: the With methods return a modified copy, so a configured builder
+// can be shared between goroutines.
+type RawEnvelopeBuilder struct {
+	clock        Clock
+	partitionKey PartitionKeyFunc
+	historical   bool
+	backfillTs   *time.Time
+}
+
+// NewRawEnvelopeBuilder returns a builder using the system clock and PartitionByInstrument
+func NewRawEnvelopeBuilder() RawEnvelopeBuilder {
+	return RawEnvelopeBuilder{}
+}
+
+// WithClock returns a builder that stamps ts_ingest_ms from the given clock
+func (b RawEnvelopeBuilder) WithClock(clock Clock) RawEnvelopeBuilder {
+	b.clock = clock
+	return b
+}
+
+// WithPartitionKey returns a builder that derives partition keys with fn
+func (b RawEnvelopeBuilder) WithPartitionKey(fn PartitionKeyFunc) RawEnvelopeBuilder {
+	b.partitionKey = fn
+	return b
+}
+
+// Historical returns a builder that marks envelopes as historical
+// (is_historical: true) without a backfill run time
+func (b RawEnvelopeBuilder) Historical() RawEnvelopeBuilder {
+	b.historical = true
+	b.backfillTs = nil
+	return b
+}
+
+// Backfill returns a builder for backfill envelopes: is_historical is set and
+// backfill_ts_ms records when the backfill job ran
+func (b RawEnvelopeBuilder) Backfill(runAt time.Time) RawEnvelopeBuilder {
+	b.historical = true
+	b.backfillTs = &runAt
+	return b
+}
+
+// Build creates and validates an envelope. The payload may be any value
+// accepted by PayloadMap.
+func (b RawEnvelopeBuilder) Build(venue VenueID, stream RawEnvelopeV0Stream, instrumentNative string, eventTS time.Time, payload any) (RawEnvelopeV0, error) {
+	clock := b.clock
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	payloadMap, err := PayloadMap(payload)
+	if err != nil {
+		return RawEnvelopeV0{}, fmt.Errorf("raw envelope: %w", err)
+	}
+
+	env := RawEnvelopeV0{
+		Schema:           RawV0,
+		VenueID:          venue,
+		Stream:           stream,
+		InstrumentNative: instrumentNative,
+		TsEventMS:        eventTS.UnixMilli(),
+		TsIngestMS:       clock.Now().UnixMilli(),
+		Payload:          payloadMap,
+	}
+	if b.historical {
+		historical := true
+		env.IsHistorical = &historical
+	}
+	if b.backfillTs != nil {
+		backfillMs := b.backfillTs.UnixMilli()
+		env.BackfillTsMS = &backfillMs
+	}
+
+	partitionKey := b.partitionKey
+	if partitionKey == nil {
+		partitionKey = PartitionByInstrument
+	}
+	if env.PartitionKey, err = partitionKey(&env); err != nil {
+		return RawEnvelopeV0{}, fmt.Errorf("raw envelope: partition key: %w", err)
+	}
+
+	if err := validateRawEnvelope(env); err != nil {
+		return RawEnvelopeV0{}, err
+	}
+	return env, nil
+}
+
+// validateRawEnvelope applies the raw.v0 schema constraints, consulting the
+// venue registry rather than the schema's venue enum
+func validateRawEnvelope(env RawEnvelopeV0) error {
+	if env.Schema != RawV0 {
+		return fmt.Errorf("raw envelope: invalid schema: %s", env.Schema)
+	}
+	if err := ValidateVenueStream(env.VenueID, env.Stream); err != nil {
+		return fmt.Errorf("raw envelope: %w", err)
+	}
+	if env.InstrumentNative == "" {
+		return fmt.Errorf("raw envelope: instrument_native is required")
+	}
+	if env.PartitionKey == "" {
+		return fmt.Errorf("raw envelope: partition_key is required")
+	}
+	if env.TsEventMS < 0 {
+		return fmt.Errorf("raw envelope: ts_event_ms must be >= 0")
+	}
+	if env.TsIngestMS < 0 {
+		return fmt.Errorf("raw envelope: ts_ingest_ms must be >= 0")
+	}
+	if env.BackfillTsMS != nil && *env.BackfillTsMS < 0 {
+		return fmt.Errorf("raw envelope: backfill_ts_ms must be >= 0")
+	}
+	if env.Payload == nil {
+		return fmt.Errorf("raw envelope: payload is required")
+	}
+	return nil
+}
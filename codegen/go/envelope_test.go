@@ -0,0 +1,109 @@
+package sundayschemas
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRawEnvelopeBuilder_Deterministic(t *testing.T) {
+	builder := NewRawEnvelopeBuilder().WithClock(FixedClock(time.UnixMilli(2000)))
+
+	env, err := builder.Build(VenueKalshi, Trades, "PRES-28", time.UnixMilli(1000), json.RawMessage(`{"yes_price":65}`))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if env.TsEventMS != 1000 || env.TsIngestMS != 2000 || env.PartitionKey != "kalshi:PRES-28" {
+		t.Errorf("Build() = %+v", env)
+	}
+	if env.IsHistorical != nil || env.BackfillTsMS != nil {
+		t.Errorf("live envelope has backfill fields: %+v", env)
+	}
+
+	data, err := env.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateJSON(SchemaRAW_V0, data); err != nil {
+		t.Errorf("built envelope fails schema validation: %v", err)
+	}
+}
+
+func TestRawEnvelopeBuilder_Backfill(t *testing.T) {
+	builder := NewRawEnvelopeBuilder().
+		WithClock(FixedClock(time.UnixMilli(5000))).
+		Backfill(time.UnixMilli(4000))
+
+	env, err := builder.Build(VenuePolymarket, Orderbook, "0x1", time.UnixMilli(1000), map[string]interface{}{"bids": []interface{}{}})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if env.IsHistorical == nil || !*env.IsHistorical || env.BackfillTsMS == nil || *env.BackfillTsMS != 4000 {
+		t.Errorf("Build() backfill fields = %v, %v", env.IsHistorical, env.BackfillTsMS)
+	}
+
+	historical, err := NewRawEnvelopeBuilder().Historical().Build(VenuePolymarket, Orderbook, "0x1", time.UnixMilli(1000), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if historical.IsHistorical == nil || !*historical.IsHistorical || historical.BackfillTsMS != nil {
+		t.Errorf("Historical() fields = %v, %v", historical.IsHistorical, historical.BackfillTsMS)
+	}
+}
+
+func TestRawEnvelopeBuilder_PartitionStrategies(t *testing.T) {
+	payload := map[string]interface{}{"event_ticker": "PRES-28", "series_ticker": "PRES", "market_id": 42.0}
+	tests := []struct {
+		name    string
+		fn      PartitionKeyFunc
+		want    string
+		wantErr bool
+	}{
+		{"venue", PartitionByVenue, "kalshi", false},
+		{"event", PartitionByPayloadField("event_ticker"), "kalshi:PRES-28", false},
+		{"series fallback", PartitionByPayloadField("series_id", "series_ticker"), "kalshi:PRES", false},
+		{"numeric field", PartitionByPayloadField("market_id"), "kalshi:42", false},
+		{"missing field", PartitionByPayloadField("slug"), "", true},
+		{"empty key", func(*RawEnvelopeV0) (string, error) { return "", nil }, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := NewRawEnvelopeBuilder().WithPartitionKey(tt.fn).Build(VenueKalshi, Trades, "PRES-28-X", time.UnixMilli(1), payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if env.PartitionKey != tt.want {
+				t.Errorf("PartitionKey = %q, want %q", env.PartitionKey, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawEnvelopeBuilder_Validation(t *testing.T) {
+	payload := map[string]interface{}{"k": "v"}
+	builder := NewRawEnvelopeBuilder()
+
+	tests := []struct {
+		name       string
+		venue      VenueID
+		stream     RawEnvelopeV0Stream
+		instrument string
+		eventTS    time.Time
+		payload    any
+	}{
+		{"unknown venue", "manifold", Trades, "x", time.UnixMilli(1), payload},
+		{"unknown stream", VenueKalshi, StreamEventDiscovery, "x", time.UnixMilli(1), payload},
+		{"empty instrument", VenueKalshi, Trades, "", time.UnixMilli(1), payload},
+		{"zero event time", VenueKalshi, Trades, "x", time.Time{}, payload},
+		{"nil payload", VenueKalshi, Trades, "x", time.UnixMilli(1), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := builder.Build(tt.venue, tt.stream, tt.instrument, tt.eventTS, tt.payload); err == nil {
+				t.Error("Build() expected error, got nil")
+			}
+		})
+	}
+}
@@ -5,7 +5,11 @@ go 1.22
 // This module contains generated Go types from Sunday platform schemas
 // Generated types should not be modified directly
 
-require github.com/oapi-codegen/runtime v1.1.2
+require (
+	github.com/oapi-codegen/runtime v1.1.2
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
+	golang.org/x/text v0.14.0
+)
 
 require (
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
@@ -0,0 +1,457 @@
+// Package sundayschemas provides the canonical instrument registry
+//
+// The registry loads the schemas/registries/instruments.json format and maps
+// canonical instrument_id values to venue-native identifiers (instrument_native)
+// in both directions, as described in docs/mapping.md.
+package sundayschemas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Instrument is a canonical instrument and its venue-native identifiers
+type Instrument struct {
+	ID      string
+	Natives map[VenueID]string
+	// Tokens holds the outcome tokens of venues that book each outcome
+	// separately, such as Polymarket
+	Tokens           map[VenueID]OutcomeTokens
+	Title            string
+	Category         string
+	ResolutionSource string
+}
+
+// Outcome is one side of a binary market
+type Outcome string
+
+const (
+	OutcomeYes Outcome = "yes"
+	OutcomeNo  Outcome = "no"
+)
+
+// OutcomeTokens are the IDs a venue books an instrument's YES and NO
+// outcomes under, e.g. a Polymarket market's two CLOB token IDs
+type OutcomeTokens struct {
+	Yes string
+	No  string
+}
+
+// Outcome returns the outcome a token ID stands for
+func (t OutcomeTokens) Outcome(token string) (Outcome, bool) {
+	switch token {
+	case "":
+		return "", false
+	case t.Yes:
+		return OutcomeYes, true
+	case t.No:
+		return OutcomeNo, true
+	default:
+		return "", false
+	}
+}
+
+// InstrumentConflict describes identifiers that cannot be resolved unambiguously
+type InstrumentConflict struct {
+	// Venue and Native are set when one native ID maps to several canonical IDs
+	Venue         VenueID
+	Native        string
+	InstrumentIDs []string
+	Reason        string
+}
+
+func (c InstrumentConflict) String() string {
+	if c.Native != "" {
+		return fmt.Sprintf("%s native %q: %s (%s)", c.Venue, c.Native, c.Reason, strings.Join(c.InstrumentIDs, ", "))
+	}
+	return fmt.Sprintf("%s (%s)", c.Reason, strings.Join(c.InstrumentIDs, ", "))
+}
+
+// InstrumentConflictError reports every conflict found while building a registry
+type InstrumentConflictError struct {
+	Conflicts []InstrumentConflict
+}
+
+func (e *InstrumentConflictError) Error() string {
+	msgs := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		msgs[i] = c.String()
+	}
+	return "instrument registry conflicts: " + strings.Join(msgs, "; ")
+}
+
+// InstrumentRegistry resolves canonical instrument IDs to venue-native IDs and back.
+// It is safe for concurrent use; reloads swap the whole index atomically, and a
+// failed reload leaves the previous contents in place. The zero value is an empty registry.
+type InstrumentRegistry struct {
+	mu    sync.RWMutex
+	index *instrumentIndex
+}
+
+type instrumentIndex struct {
+	byID     map[string]Instrument
+	byNative map[VenueID]map[string]string
+}
+
+// NewInstrumentRegistry creates a registry populated with the given instruments
+func NewInstrumentRegistry(instruments ...Instrument) (*InstrumentRegistry, error) {
+	r := &InstrumentRegistry{}
+	if err := r.Replace(instruments); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// LoadInstrumentRegistry creates a registry from an instruments.json file
+func LoadInstrumentRegistry(path string) (*InstrumentRegistry, error) {
+	r := &InstrumentRegistry{}
+	if err := r.LoadFile(path); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ParseInstruments parses an instruments.json document. The "instruments" field
+// may be an array of entries carrying "instrument_id", or an object keyed by
+// instrument_id. In both forms venue keys map to native IDs, and
+// {venue}_yes_token and {venue}_no_token keys to outcome tokens, e.g.
+//
+//	{"instrument_id": "pm_us_election_2028_winner", "polymarket": "0x1234...", "kalshi": "PRES-28",
+//	 "polymarket_yes_token": "7132...", "polymarket_no_token": "6620..."}
+func ParseInstruments(data []byte) ([]Instrument, error) {
+	var doc struct {
+		Instruments json.RawMessage `json:"instruments"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse instruments: %w", err)
+	}
+
+	raw := bytes.TrimSpace(doc.Instruments)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	var entries []map[string]any
+	if raw[0] == '[' {
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse instruments: %w", err)
+		}
+	} else {
+		var byID map[string]map[string]any
+		if err := json.Unmarshal(raw, &byID); err != nil {
+			return nil, fmt.Errorf("failed to parse instruments: %w", err)
+		}
+		ids := make([]string, 0, len(byID))
+		for id := range byID {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			entry := byID[id]
+			if entry == nil {
+				entry = map[string]any{}
+			}
+			entry["instrument_id"] = id
+			entries = append(entries, entry)
+		}
+	}
+
+	instruments := make([]Instrument, 0, len(entries))
+	for i, entry := range entries {
+		inst, err := parseInstrumentEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("instruments[%d]: %w", i, err)
+		}
+		instruments = append(instruments, inst)
+	}
+
+	return instruments, nil
+}
+
+func parseInstrumentEntry(entry map[string]any) (Instrument, error) {
+	inst := Instrument{Natives: make(map[VenueID]string)}
+
+	for key, value := range entry {
+		s, ok := value.(string)
+		if !ok {
+			if value == nil {
+				continue
+			}
+			return Instrument{}, fmt.Errorf("field %q must be a string", key)
+		}
+
+		switch key {
+		case "instrument_id":
+			inst.ID = s
+		case "title":
+			inst.Title = s
+		case "category":
+			inst.Category = s
+		case "resolution_source":
+			inst.ResolutionSource = s
+		default:
+			if venue, outcome, ok := tokenKey(key); ok {
+				if err := ValidateVenue(venue); err != nil {
+					return Instrument{}, fmt.Errorf("unknown field %q: %w", key, err)
+				}
+				if inst.Tokens == nil {
+					inst.Tokens = make(map[VenueID]OutcomeTokens)
+				}
+				tokens := inst.Tokens[VenueID(venue)]
+				if outcome == OutcomeYes {
+					tokens.Yes = s
+				} else {
+					tokens.No = s
+				}
+				inst.Tokens[VenueID(venue)] = tokens
+				continue
+			}
+			if err := ValidateVenue(key); err != nil {
+				return Instrument{}, fmt.Errorf("unknown field %q: %w", key, err)
+			}
+			inst.Natives[VenueID(key)] = s
+		}
+	}
+
+	return inst, nil
+}
+
+// tokenKey splits a {venue}_yes_token or {venue}_no_token key
+func tokenKey(key string) (string, Outcome, bool) {
+	if venue, ok := strings.CutSuffix(key, "_yes_token"); ok {
+		return venue, OutcomeYes, true
+	}
+	if venue, ok := strings.CutSuffix(key, "_no_token"); ok {
+		return venue, OutcomeNo, true
+	}
+	return "", "", false
+}
+
+// Load parses an instruments.json document and atomically replaces the registry contents
+func (r *InstrumentRegistry) Load(data []byte) error {
+	instruments, err := ParseInstruments(data)
+	if err != nil {
+		return err
+	}
+	return r.Replace(instruments)
+}
+
+// LoadFile reads an instruments.json file and atomically replaces the registry contents
+func (r *InstrumentRegistry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read instruments: %w", err)
+	}
+	return r.Load(data)
+}
+
+// Replace validates the instruments and atomically swaps them into the registry.
+// Conflicts are reported as *InstrumentConflictError and leave the registry unchanged.
+func (r *InstrumentRegistry) Replace(instruments []Instrument) error {
+	index, err := buildInstrumentIndex(instruments)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.index = index
+	r.mu.Unlock()
+	return nil
+}
+
+func buildInstrumentIndex(instruments []Instrument) (*instrumentIndex, error) {
+	index := &instrumentIndex{
+		byID:     make(map[string]Instrument, len(instruments)),
+		byNative: make(map[VenueID]map[string]string),
+	}
+
+	var conflicts []InstrumentConflict
+	owners := make(map[VenueID]map[string][]string)
+
+	for _, inst := range instruments {
+		if inst.ID == "" {
+			return nil, fmt.Errorf("instrument has empty instrument_id")
+		}
+		if _, dup := index.byID[inst.ID]; dup {
+			conflicts = append(conflicts, InstrumentConflict{
+				InstrumentIDs: []string{inst.ID},
+				Reason:        "duplicate instrument_id",
+			})
+			continue
+		}
+
+		natives := make(map[VenueID]string, len(inst.Natives))
+		for venue, native := range inst.Natives {
+			if err := ValidateVenue(string(venue)); err != nil {
+				return nil, fmt.Errorf("instrument %s: %w", inst.ID, err)
+			}
+			if native == "" {
+				return nil, fmt.Errorf("instrument %s: empty native ID for %s", inst.ID, venue)
+			}
+			natives[venue] = native
+			if owners[venue] == nil {
+				owners[venue] = make(map[string][]string)
+			}
+			owners[venue][native] = append(owners[venue][native], inst.ID)
+		}
+		inst.Natives = natives
+		for venue, t := range inst.Tokens {
+			if err := ValidateVenue(string(venue)); err != nil {
+				return nil, fmt.Errorf("instrument %s: %w", inst.ID, err)
+			}
+			if t.Yes == "" || t.No == "" || t.Yes == t.No {
+				return nil, fmt.Errorf("instrument %s: %s needs distinct yes and no tokens", inst.ID, venue)
+			}
+		}
+		inst = copyInstrument(inst)
+		index.byID[inst.ID] = inst
+	}
+
+	for venue, byNative := range owners {
+		index.byNative[venue] = make(map[string]string, len(byNative))
+		for native, ids := range byNative {
+			if len(ids) > 1 {
+				sort.Strings(ids)
+				conflicts = append(conflicts, InstrumentConflict{
+					Venue:         venue,
+					Native:        native,
+					InstrumentIDs: ids,
+					Reason:        "native ID mapped to multiple instruments",
+				})
+				continue
+			}
+			index.byNative[venue][native] = ids[0]
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].String() < conflicts[j].String() })
+		return nil, &InstrumentConflictError{Conflicts: conflicts}
+	}
+
+	return index, nil
+}
+
+func (r *InstrumentRegistry) current() *instrumentIndex {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.index == nil {
+		return &instrumentIndex{}
+	}
+	return r.index
+}
+
+// Native returns the venue-native identifier for a canonical instrument
+func (r *InstrumentRegistry) Native(instrumentID string, venue VenueID) (string, bool) {
+	inst, ok := r.current().byID[instrumentID]
+	if !ok {
+		return "", false
+	}
+	native, ok := inst.Natives[venue]
+	return native, ok
+}
+
+// TokenOutcome returns the outcome a venue token ID stands for in a canonical
+// instrument. It reports false when the instrument has no tokens on the venue
+// or token is neither of them.
+func (r *InstrumentRegistry) TokenOutcome(instrumentID string, venue VenueID, token string) (Outcome, bool) {
+	inst, ok := r.current().byID[instrumentID]
+	if !ok {
+		return "", false
+	}
+	return inst.Tokens[venue].Outcome(token)
+}
+
+// Canonical returns the canonical instrument_id for a venue-native identifier
+func (r *InstrumentRegistry) Canonical(venue VenueID, native string) (string, bool) {
+	id, ok := r.current().byNative[venue][native]
+	return id, ok
+}
+
+// Instrument returns the registry entry for a canonical instrument
+func (r *InstrumentRegistry) Instrument(instrumentID string) (Instrument, bool) {
+	inst, ok := r.current().byID[instrumentID]
+	if !ok {
+		return Instrument{}, false
+	}
+	return copyInstrument(inst), true
+}
+
+// Instruments returns every registered instrument sorted by instrument_id
+func (r *InstrumentRegistry) Instruments() []Instrument {
+	index := r.current()
+	out := make([]Instrument, 0, len(index.byID))
+	for _, inst := range index.byID {
+		out = append(out, copyInstrument(inst))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Len returns the number of registered instruments
+func (r *InstrumentRegistry) Len() int {
+	return len(r.current().byID)
+}
+
+// WatchFile loads the file, then reloads the registry whenever the file's size
+// or modification time changes, polling at the given interval until ctx is
+// cancelled. Load errors are passed to onError (if non-nil) and the previous
+// contents stay in place.
+func (r *InstrumentRegistry) WatchFile(ctx context.Context, path string, interval time.Duration, onError func(error)) {
+	var lastMod time.Time
+	var lastSize int64 = -1
+	if info, err := os.Stat(path); err == nil {
+		lastMod, lastSize = info.ModTime(), info.Size()
+	}
+	if err := r.LoadFile(path); err != nil && onError != nil {
+		onError(err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				continue
+			}
+			if info.ModTime().Equal(lastMod) && info.Size() == lastSize {
+				continue
+			}
+			lastMod, lastSize = info.ModTime(), info.Size()
+			if err := r.LoadFile(path); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func copyInstrument(inst Instrument) Instrument {
+	natives := make(map[VenueID]string, len(inst.Natives))
+	for venue, native := range inst.Natives {
+		natives[venue] = native
+	}
+	inst.Natives = natives
+	if inst.Tokens != nil {
+		tokens := make(map[VenueID]OutcomeTokens, len(inst.Tokens))
+		for venue, t := range inst.Tokens {
+			tokens[venue] = t
+		}
+		inst.Tokens = tokens
+	}
+	return inst
+}
@@ -0,0 +1,213 @@
+package sundayschemas
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testInstrumentsJSON = `{
+  "_comment": "test registry",
+  "instruments": [
+    {"instrument_id": "pm_us_election_2028_winner", "polymarket": "0x1234", "kalshi": "PRES-28", "title": "US Presidential Election 2028 Winner", "category": "politics"},
+    {"instrument_id": "pm_crypto_btc_100k_2025", "polymarket": "0x5678"}
+  ]
+}`
+
+func TestInstrumentRegistry_BothDirections(t *testing.T) {
+	r := &InstrumentRegistry{}
+	if err := r.Load([]byte(testInstrumentsJSON)); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got, ok := r.Canonical(VenueKalshi, "PRES-28"); !ok || got != "pm_us_election_2028_winner" {
+		t.Errorf("Canonical(kalshi, PRES-28) = (%q, %v)", got, ok)
+	}
+	if got, ok := r.Native("pm_us_election_2028_winner", VenuePolymarket); !ok || got != "0x1234" {
+		t.Errorf("Native(pm_us_election_2028_winner, polymarket) = (%q, %v)", got, ok)
+	}
+	if _, ok := r.Native("pm_crypto_btc_100k_2025", VenueKalshi); ok {
+		t.Error("Native() expected miss for venue without mapping")
+	}
+	if _, ok := r.Canonical(VenueKalshi, "0x1234"); ok {
+		t.Error("Canonical() expected miss for native ID of another venue")
+	}
+
+	inst, ok := r.Instrument("pm_us_election_2028_winner")
+	if !ok || inst.Title != "US Presidential Election 2028 Winner" || inst.Category != "politics" {
+		t.Errorf("Instrument() = (%+v, %v)", inst, ok)
+	}
+	inst.Natives[VenueKalshi] = "MUTATED"
+	if got, _ := r.Native("pm_us_election_2028_winner", VenueKalshi); got != "PRES-28" {
+		t.Error("Instrument() returned a map shared with the registry")
+	}
+
+	if r.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", r.Len())
+	}
+}
+
+func TestParseInstruments_ObjectForm(t *testing.T) {
+	instruments, err := ParseInstruments([]byte(`{"instruments": {"pm_us_election_2028_winner": {"polymarket": "0x1234", "kalshi": "PRES-28"}}}`))
+	if err != nil {
+		t.Fatalf("ParseInstruments() error = %v", err)
+	}
+	if len(instruments) != 1 || instruments[0].ID != "pm_us_election_2028_winner" || instruments[0].Natives[VenueKalshi] != "PRES-28" {
+		t.Errorf("ParseInstruments() = %+v", instruments)
+	}
+}
+
+func TestInstrumentRegistry_TokenOutcome(t *testing.T) {
+	r := &InstrumentRegistry{}
+	err := r.Load([]byte(`{"instruments": [{"instrument_id": "pm_x", "polymarket": "0x1234", "polymarket_yes_token": "111", "polymarket_no_token": "222"}]}`))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	tests := []struct {
+		venue  VenueID
+		token  string
+		want   Outcome
+		wantOK bool
+	}{
+		{VenuePolymarket, "111", OutcomeYes, true},
+		{VenuePolymarket, "222", OutcomeNo, true},
+		{VenuePolymarket, "333", "", false},
+		{VenuePolymarket, "", "", false},
+		{VenueKalshi, "111", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := r.TokenOutcome("pm_x", tt.venue, tt.token); got != tt.want || ok != tt.wantOK {
+			t.Errorf("TokenOutcome(%s, %q) = (%q, %v), want (%q, %v)", tt.venue, tt.token, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if err := r.Load([]byte(`{"instruments": [{"instrument_id": "pm_x", "polymarket_yes_token": "111"}]}`)); err == nil {
+		t.Error("Load() expected error for a yes token without a no token")
+	}
+	if err := r.Load([]byte(`{"instruments": [{"instrument_id": "pm_x", "manifold_no_token": "1"}]}`)); err == nil {
+		t.Error("Load() expected error for tokens of an unknown venue")
+	}
+}
+
+func TestParseInstruments_RepositoryRegistry(t *testing.T) {
+	r, err := LoadInstrumentRegistry(filepath.Join("..", "..", "schemas", "registries", "instruments.json"))
+	if err != nil {
+		t.Fatalf("LoadInstrumentRegistry() error = %v", err)
+	}
+	if r.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", r.Len())
+	}
+}
+
+func TestParseInstruments_UnknownVenue(t *testing.T) {
+	if _, err := ParseInstruments([]byte(`{"instruments": [{"instrument_id": "x", "manifold": "abc"}]}`)); err == nil {
+		t.Error("ParseInstruments() expected error for unknown venue key, got nil")
+	}
+}
+
+func TestInstrumentRegistry_Conflicts(t *testing.T) {
+	_, err := NewInstrumentRegistry(
+		Instrument{ID: "a", Natives: map[VenueID]string{VenueKalshi: "PRES-28"}},
+		Instrument{ID: "b", Natives: map[VenueID]string{VenueKalshi: "PRES-28"}},
+		Instrument{ID: "c", Natives: map[VenueID]string{VenuePolymarket: "0x1"}},
+		Instrument{ID: "c", Natives: map[VenueID]string{VenuePolymarket: "0x2"}},
+	)
+
+	var conflictErr *InstrumentConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("NewInstrumentRegistry() error = %v, want *InstrumentConflictError", err)
+	}
+	if len(conflictErr.Conflicts) != 2 {
+		t.Fatalf("got %d conflicts, want 2: %v", len(conflictErr.Conflicts), conflictErr)
+	}
+
+	var native *InstrumentConflict
+	for i := range conflictErr.Conflicts {
+		if conflictErr.Conflicts[i].Native == "PRES-28" {
+			native = &conflictErr.Conflicts[i]
+		}
+	}
+	if native == nil || native.Venue != VenueKalshi || len(native.InstrumentIDs) != 2 {
+		t.Errorf("missing native conflict for PRES-28: %v", conflictErr.Conflicts)
+	}
+}
+
+func TestInstrumentRegistry_FailedReloadKeepsContents(t *testing.T) {
+	r, err := NewInstrumentRegistry(Instrument{ID: "a", Natives: map[VenueID]string{VenueKalshi: "A"}})
+	if err != nil {
+		t.Fatalf("NewInstrumentRegistry() error = %v", err)
+	}
+
+	err = r.Load([]byte(`{"instruments": [{"instrument_id": "x", "kalshi": "DUP"}, {"instrument_id": "y", "kalshi": "DUP"}]}`))
+	if err == nil {
+		t.Fatal("Load() expected conflict error, got nil")
+	}
+	if got, ok := r.Canonical(VenueKalshi, "A"); !ok || got != "a" {
+		t.Errorf("registry contents changed after failed reload: (%q, %v)", got, ok)
+	}
+}
+
+func TestInstrumentRegistry_ConcurrentReload(t *testing.T) {
+	r := &InstrumentRegistry{}
+	first := []Instrument{{ID: "a", Natives: map[VenueID]string{VenueKalshi: "A"}}}
+	second := []Instrument{{ID: "a", Natives: map[VenueID]string{VenueKalshi: "A"}}, {ID: "b", Natives: map[VenueID]string{VenueKalshi: "B"}}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			set := first
+			if i%2 == 0 {
+				set = second
+			}
+			if err := r.Replace(set); err != nil {
+				t.Errorf("Replace() error = %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Canonical(VenueKalshi, "A")
+				r.Instruments()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, ok := r.Canonical(VenueKalshi, "A"); !ok || got != "a" {
+		t.Errorf("Canonical() = (%q, %v), want a", got, ok)
+	}
+}
+
+func TestInstrumentRegistry_WatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instruments.json")
+	if err := os.WriteFile(path, []byte(`{"instruments": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LoadInstrumentRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadInstrumentRegistry() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.WatchFile(ctx, path, 5*time.Millisecond, nil)
+
+	if err := os.WriteFile(path, []byte(testInstrumentsJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for r.Len() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("WatchFile() did not reload the registry")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,46 @@
+// Package sundayschemas exposes monetary and quantity fields as exact decimals
+package sundayschemas
+
+import (
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/money"
+)
+
+// NotionalAmount returns notional_usd as exact USD. ok is false when the
+// trade has no notional; sub-cent notionals are rejected with a
+// *money.PrecisionError.
+func (t NormalizedTradeV1) NotionalAmount() (notional money.Money, ok bool, err error) {
+	if t.NotionalUsd == nil {
+		return money.Money{}, false, nil
+	}
+	notional, err = money.USDFromFloat(*t.NotionalUsd)
+	return notional, err == nil, err
+}
+
+// SizeDecimal returns the trade size as an exact decimal
+func (t NormalizedTradeV1) SizeDecimal() (money.Decimal, error) {
+	return money.FromFloat(t.Size)
+}
+
+// ProbDecimal returns the trade probability as an exact decimal
+func (t NormalizedTradeV1) ProbDecimal() (money.Decimal, error) {
+	return money.FromFloat(t.Prob)
+}
+
+// EdgeBpsDecimal returns edge_bps as an exact decimal
+func (a ArbitrageLiteV1) EdgeBpsDecimal() (money.Decimal, error) {
+	return money.FromFloat(a.EdgeBps)
+}
+
+// usdNotional returns prob × size × contractSize in USD, rounded half away
+// from zero to cents without float drift
+func usdNotional(prob, size, contractSize float64) (float64, error) {
+	factors := make([]money.Decimal, 3)
+	for i, f := range []float64{prob, size, contractSize} {
+		d, err := money.FromFloat(f)
+		if err != nil {
+			return 0, err
+		}
+		factors[i] = d
+	}
+	return factors[0].Mul(factors[1]).Mul(factors[2]).Round(2).Float64(), nil
+}
@@ -0,0 +1,167 @@
+package money
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Currency is an ISO 4217 currency code or a token symbol such as USDC
+type Currency string
+
+const (
+	// CurrencyUSD is the currency of every *_usd schema field
+	CurrencyUSD Currency = "USD"
+	// CurrencyUSDC is the USD Coin stablecoin Polymarket settles in
+	CurrencyUSDC Currency = "USDC"
+	// CurrencyEUR is the euro
+	CurrencyEUR Currency = "EUR"
+	// CurrencyGBP is the pound sterling
+	CurrencyGBP Currency = "GBP"
+)
+
+// MinorUnits returns the number of decimal places c allows in the default
+// currency registry, e.g. 2 for USD
+func (c Currency) MinorUnits() (int32, bool) {
+	info, ok := LookupCurrency(c)
+	return info.MinorUnits, ok
+}
+
+// IsValid reports whether c is in the default currency registry
+func (c Currency) IsValid() bool {
+	return IsValidCurrency(c)
+}
+
+// CurrencyInfo describes a currency
+type CurrencyInfo struct {
+	Code Currency `json:"code"`
+	Name string   `json:"name,omitempty"`
+	// MinorUnits is the number of decimal places amounts may carry, e.g. 2
+	// for cents or 6 for USDC's on-chain precision
+	MinorUnits int32 `json:"minor_units"`
+}
+
+// Validate checks that a currency description is complete
+func (c CurrencyInfo) Validate() error {
+	if c.Code == "" {
+		return fmt.Errorf("currency has empty code")
+	}
+	if c.MinorUnits < 0 || c.MinorUnits > 18 {
+		return fmt.Errorf("currency %s: minor units must be in [0, 18]", c.Code)
+	}
+	return nil
+}
+
+// Quantum returns the smallest amount of the currency, e.g. 0.01 for USD
+func (c CurrencyInfo) Quantum() Decimal {
+	return New(1, c.MinorUnits)
+}
+
+// builtinCurrencies describes the currencies known to this package
+var builtinCurrencies = []CurrencyInfo{
+	{Code: CurrencyUSD, Name: "US Dollar", MinorUnits: 2},
+	{Code: CurrencyUSDC, Name: "USD Coin", MinorUnits: 6},
+	{Code: CurrencyEUR, Name: "Euro", MinorUnits: 2},
+	{Code: CurrencyGBP, Name: "Pound Sterling", MinorUnits: 2},
+}
+
+// CurrencyRegistry holds currency descriptions in registration order. It is
+// safe for concurrent use. The zero value is an empty registry.
+type CurrencyRegistry struct {
+	mu     sync.RWMutex
+	byCode map[Currency]CurrencyInfo
+	order  []Currency
+}
+
+// NewCurrencyRegistry creates a registry populated with the given currencies
+func NewCurrencyRegistry(currencies ...CurrencyInfo) (*CurrencyRegistry, error) {
+	r := &CurrencyRegistry{}
+	for _, c := range currencies {
+		if err := r.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a currency. Registering a code twice is an error.
+func (r *CurrencyRegistry) Register(c CurrencyInfo) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if c.Name == "" {
+		c.Name = string(c.Code)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.byCode[c.Code]; dup {
+		return fmt.Errorf("currency %s is already registered", c.Code)
+	}
+	if r.byCode == nil {
+		r.byCode = make(map[Currency]CurrencyInfo)
+	}
+	r.byCode[c.Code] = c
+	r.order = append(r.order, c.Code)
+	return nil
+}
+
+// Lookup returns the description of a currency
+func (r *CurrencyRegistry) Lookup(code Currency) (CurrencyInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.byCode[code]
+	return c, ok
+}
+
+// IsValid reports whether a currency is registered
+func (r *CurrencyRegistry) IsValid(code Currency) bool {
+	_, ok := r.Lookup(code)
+	return ok
+}
+
+// Codes returns every registered currency code in registration order
+func (r *CurrencyRegistry) Codes() []Currency {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Currency(nil), r.order...)
+}
+
+var (
+	defaultCurrencies     *CurrencyRegistry
+	defaultCurrenciesOnce sync.Once
+)
+
+// DefaultCurrencies returns the process-wide registry, populated with USD,
+// USDC, EUR and GBP. Currencies registered on it are accepted by NewMoney and
+// the discovery validators.
+func DefaultCurrencies() *CurrencyRegistry {
+	defaultCurrenciesOnce.Do(func() {
+		r, err := NewCurrencyRegistry(builtinCurrencies...)
+		if err != nil {
+			panic(err)
+		}
+		defaultCurrencies = r
+	})
+	return defaultCurrencies
+}
+
+// RegisterCurrency adds a currency to the default registry
+func RegisterCurrency(c CurrencyInfo) error {
+	return DefaultCurrencies().Register(c)
+}
+
+// LookupCurrency returns the description of a currency from the default
+// registry
+func LookupCurrency(code Currency) (CurrencyInfo, bool) {
+	return DefaultCurrencies().Lookup(code)
+}
+
+// IsValidCurrency reports whether a currency is in the default registry
+func IsValidCurrency(code Currency) bool {
+	return DefaultCurrencies().IsValid(code)
+}
+
+// Currencies returns every currency code in the default registry
+func Currencies() []Currency {
+	return DefaultCurrencies().Codes()
+}
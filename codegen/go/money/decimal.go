@@ -0,0 +1,329 @@
+// Package money provides exact decimal and monetary types for Sunday platform schemas
+//
+// Schema fields such as volume_24h_usd and notional_usd are JSON numbers and
+// decode into float64 in the generated types. Decimal and Money hold the same
+// values exactly, so cent precision can be checked without an epsilon and sums
+// do not drift. Both marshal to the same JSON numbers as the float fields.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero is returned by Div when the divisor is zero
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Parse limits, far beyond any amount or price, so that a literal such as
+// "1e10000000" is rejected instead of expanded into millions of digits
+const (
+	maxExponent = 1000 // |exponent| of a literal
+	maxScale    = 1000 // |scale| of the parsed value, i.e. digits after the point or zeros before it
+)
+
+// Decimal is an exact decimal number: coef × 10^-scale. The zero value is 0.
+// Decimals are immutable; every operation returns a new value.
+type Decimal struct {
+	coef  *big.Int // nil means 0
+	scale int32    // digits after the decimal point, >= 0
+}
+
+// New returns units × 10^-scale, e.g. New(150, 2) is 1.50
+func New(units int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(units), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(units), scale: scale}
+}
+
+// NewFromInt returns n as a Decimal
+func NewFromInt(n int64) Decimal {
+	return New(n, 0)
+}
+
+// Parse reads a decimal literal in JSON number syntax, e.g. "-12.50" or "1e3".
+// The scale of the literal is kept, so "1.50" has scale 2. Exponents and
+// scales beyond ±1000 are rejected.
+func Parse(s string) (Decimal, error) {
+	if !isNumberLiteral(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil || exp > maxExponent || exp < -maxExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", s)
+		}
+		mantissa = s[:i]
+	}
+	scale := int64(0)
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		scale = int64(len(mantissa) - i - 1)
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+	coef, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale -= exp
+	if scale > maxScale || scale < -maxScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", s)
+	}
+	if scale < 0 {
+		return Decimal{coef: coef.Mul(coef, pow10(int32(-scale)))}, nil
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParse is like Parse but panics on invalid input. It is meant for
+// constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// FromFloat returns the shortest decimal that round-trips to f, which is the
+// number encoding/json writes for f. A float64 decoded from the JSON literal
+// 0.1 becomes exactly 0.1.
+func FromFloat(f float64) (Decimal, error) {
+	return fromFloat(f, 64)
+}
+
+// FromFloat32 is like FromFloat for float32 fields, such as the API models
+func FromFloat32(f float32) (Decimal, error) {
+	return fromFloat(float64(f), 32)
+}
+
+func fromFloat(f float64, bits int) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("invalid decimal %v", f)
+	}
+	return Parse(strconv.FormatFloat(f, 'g', -1, bits))
+}
+
+// isNumberLiteral reports whether s matches the JSON number grammar
+func isNumberLiteral(s string) bool {
+	i := 0
+	digits := func() int {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	if digits() == 0 {
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		if digits() == 0 {
+			return false
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns d's coefficient at a larger or equal scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{coef: new(big.Int).Add(x, y), scale: scale}
+}
+
+// Sub returns d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{coef: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// Mul returns d × o exactly; the scale is the sum of both scales
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Div returns d ÷ o rounded half away from zero to scale digits
+func (d Decimal) Div(o Decimal, scale int32) (Decimal, error) {
+	if o.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// d/o = (d.coef × 10^(scale + o.scale - d.scale)) / o.coef × 10^-scale,
+	// computed with one extra digit for rounding
+	num := new(big.Int).Set(d.int())
+	shift := scale + 1 + o.scale - d.scale
+	if shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		num.Quo(num, pow10(-shift))
+	}
+	q := num.Quo(num, o.int())
+	return Decimal{coef: q, scale: scale + 1}.Round(scale), nil
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Round returns d rounded half away from zero to scale digits after the
+// decimal point. A larger scale pads d with zeros.
+func (d Decimal) Round(scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale >= d.scale {
+		return Decimal{coef: d.rescale(scale), scale: scale}
+	}
+	div := pow10(d.scale - scale)
+	q, r := new(big.Int).QuoRem(d.int(), div, new(big.Int))
+	// |r| * 2 >= div rounds away from zero
+	if r.Abs(r).Lsh(r, 1).Cmp(div) >= 0 {
+		if d.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{coef: q, scale: scale}
+}
+
+// Scale returns the number of digits after the decimal point, including
+// trailing zeros
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Places returns the number of significant digits after the decimal point,
+// e.g. 2 for both 1.25 and 1.250
+func (d Decimal) Places() int32 {
+	return d.normalize().scale
+}
+
+// normalize strips trailing zeros after the decimal point
+func (d Decimal) normalize() Decimal {
+	coef, scale := new(big.Int).Set(d.int()), d.scale
+	ten, r := big.NewInt(10), new(big.Int)
+	for scale > 0 {
+		q, rem := new(big.Int).QuoRem(coef, ten, r)
+		if rem.Sign() != 0 {
+			break
+		}
+		coef, scale = q, scale-1
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and o numerically, ignoring scale: -1 if d < o, 0 if equal,
+// +1 if d > o
+func (d Decimal) Cmp(o Decimal) int {
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+// Equal reports whether d and o are numerically equal, so 1.5 equals 1.50
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Float64 returns the nearest float64
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Float32 returns the nearest float32
+func (d Decimal) Float32() float32 {
+	f, _ := strconv.ParseFloat(d.String(), 32)
+	return float32(f)
+}
+
+// String returns d in plain decimal notation with its scale, e.g. "1.50"
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(s) + 1; pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON writes d as a plain JSON number without trailing zeros. It
+// matches encoding/json's output for float64 values between 1e-6 and 1e21,
+// which covers prices, sizes and USD amounts.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.normalize().String()), nil
+}
+
+// UnmarshalJSON reads a JSON number exactly, keeping its scale
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,157 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in    string
+		want  string
+		scale int32
+	}{
+		{"0", "0", 0},
+		{"1.50", "1.50", 2},
+		{"-12.5", "-12.5", 1},
+		{"0.001", "0.001", 3},
+		{"1e3", "1000", 0},
+		{"1.5E-2", "0.015", 3},
+	}
+	for _, c := range cases {
+		d, err := Parse(c.in)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", c.in, err)
+			continue
+		}
+		if d.String() != c.want || d.Scale() != c.scale {
+			t.Errorf("Parse(%q) = %s (scale %d), want %s (scale %d)", c.in, d, d.Scale(), c.want, c.scale)
+		}
+	}
+	for _, in := range []string{"", "-", "1.", ".5", "+1", "01x", "1e", "NaN", "1e99999999999"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", in)
+		}
+	}
+}
+
+func TestParse_ExponentLimit(t *testing.T) {
+	for _, in := range []string{"1e1000", "1e-1000", "5e-324", "1.7976931348623157e308"} {
+		if _, err := Parse(in); err != nil {
+			t.Errorf("Parse(%q) error = %v", in, err)
+		}
+	}
+	// Would expand into millions of digits, or a scale past the limit
+	start := time.Now()
+	for _, in := range []string{"1e10000000", "1e-10000000", "1e1001", "0.1e-1000", "0." + strings.Repeat("0", 1000) + "1"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", in)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("rejecting huge exponents took %v", elapsed)
+	}
+}
+
+func TestDecimal_ExactArithmetic(t *testing.T) {
+	sum := MustParse("0.1").Add(MustParse("0.2"))
+	if !sum.Equal(MustParse("0.3")) {
+		t.Errorf("0.1 + 0.2 = %s, want 0.3", sum)
+	}
+	if got := MustParse("1.5").Sub(MustParse("2.25")); got.String() != "-0.75" {
+		t.Errorf("1.5 - 2.25 = %s", got)
+	}
+	if got := MustParse("0.285").Mul(NewFromInt(3)); got.String() != "0.855" {
+		t.Errorf("0.285 × 3 = %s", got)
+	}
+	if !MustParse("1.5").Equal(MustParse("1.50")) || MustParse("1.5").Cmp(MustParse("1.49")) != 1 {
+		t.Error("Equal/Cmp should ignore scale")
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	cases := []struct {
+		in    string
+		scale int32
+		want  string
+	}{
+		{"0.855", 2, "0.86"},
+		{"0.845", 2, "0.85"},
+		{"-0.845", 2, "-0.85"},
+		{"0.844", 2, "0.84"},
+		{"1.5", 0, "2"},
+		{"-1.5", 0, "-2"},
+		{"1.5", 3, "1.500"},
+	}
+	for _, c := range cases {
+		if got := MustParse(c.in).Round(c.scale).String(); got != c.want {
+			t.Errorf("Round(%s, %d) = %s, want %s", c.in, c.scale, got, c.want)
+		}
+	}
+}
+
+func TestDecimal_Div(t *testing.T) {
+	got, err := NewFromInt(2).Div(NewFromInt(3), 4)
+	if err != nil || got.String() != "0.6667" {
+		t.Errorf("2 ÷ 3 = %s, %v, want 0.6667", got, err)
+	}
+	got, err = MustParse("-1.00").Div(MustParse("0.08"), 1)
+	if err != nil || got.String() != "-12.5" {
+		t.Errorf("-1.00 ÷ 0.08 = %s, %v, want -12.5", got, err)
+	}
+	if _, err := NewFromInt(1).Div(Decimal{}, 2); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Div by zero error = %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestDecimal_Places(t *testing.T) {
+	if p := MustParse("1.250").Places(); p != 2 {
+		t.Errorf("Places(1.250) = %d, want 2", p)
+	}
+	if p := MustParse("100").Places(); p != 0 {
+		t.Errorf("Places(100) = %d, want 0", p)
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	for _, f := range []float64{0.1, 0.3, 125000.75, 1e-6, -42, 0.57} {
+		d, err := FromFloat(f)
+		if err != nil {
+			t.Fatalf("FromFloat(%v) error = %v", f, err)
+		}
+		want, _ := json.Marshal(f)
+		got, _ := json.Marshal(d)
+		if string(got) != string(want) {
+			t.Errorf("FromFloat(%v) marshals to %s, want %s", f, got, want)
+		}
+		if d.Float64() != f {
+			t.Errorf("FromFloat(%v).Float64() = %v", f, d.Float64())
+		}
+	}
+	d, err := FromFloat32(0.1)
+	if err != nil || d.String() != "0.1" {
+		t.Errorf("FromFloat32(0.1) = %s, %v", d, err)
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		Amount Decimal `json:"amount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 12.50}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Amount.Scale() != 2 {
+		t.Errorf("scale = %d, want 2", v.Amount.Scale())
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"amount":12.5}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount": "12.5"}`), &v); err == nil {
+		t.Error("Unmarshal of a string should fail")
+	}
+}
@@ -0,0 +1,76 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoRate is returned by a converter that has no rate for a currency pair
+var ErrNoRate = errors.New("no exchange rate")
+
+// FXConverter converts amounts between currencies. Implementations decide
+// where rates come from, e.g. a fixed table, a price feed or a snapshot taken
+// at discovery time, and return an error wrapping ErrNoRate for unsupported
+// pairs.
+type FXConverter interface {
+	Convert(amount Money, to Currency) (Money, error)
+}
+
+type currencyPair struct {
+	from, to Currency
+}
+
+// FixedRates is an FXConverter backed by a fixed rate table. It is immutable;
+// WithRate returns a new table. The zero value converts only between a
+// currency and itself.
+type FixedRates struct {
+	rates map[currencyPair]Decimal
+}
+
+// NewFixedRates returns an empty rate table
+func NewFixedRates() FixedRates {
+	return FixedRates{}
+}
+
+// WithRate returns a copy of the table where one unit of from is worth rate
+// units of to, e.g. WithRate(CurrencyEUR, CurrencyUSD, MustParse("1.08")).
+// The inverse pair is not added.
+func (f FixedRates) WithRate(from, to Currency, rate Decimal) FixedRates {
+	rates := make(map[currencyPair]Decimal, len(f.rates)+1)
+	for pair, r := range f.rates {
+		rates[pair] = r
+	}
+	rates[currencyPair{from, to}] = rate
+	return FixedRates{rates: rates}
+}
+
+// Rate returns the value of one unit of from in to. Every currency converts
+// to itself at 1.
+func (f FixedRates) Rate(from, to Currency) (Decimal, bool) {
+	if from == to {
+		return NewFromInt(1), true
+	}
+	rate, ok := f.rates[currencyPair{from, to}]
+	return rate, ok
+}
+
+// Convert returns amount in the target currency, rounded half away from zero
+// to the target's minor units
+func (f FixedRates) Convert(amount Money, to Currency) (Money, error) {
+	rate, ok := f.Rate(amount.Currency(), to)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s to %s", ErrNoRate, amount.Currency(), to)
+	}
+	return ConvertAt(amount, to, rate)
+}
+
+// ConvertAt returns amount × rate in the target currency, rounded half away
+// from zero to the target's minor units. It is a building block for
+// FXConverter implementations.
+func ConvertAt(amount Money, to Currency, rate Decimal) (Money, error) {
+	places, ok := to.MinorUnits()
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", to)
+	}
+	return Money{amount: amount.Amount().Mul(rate).Round(places), currency: to}, nil
+}
@@ -0,0 +1,66 @@
+package money
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFixedRates_Convert(t *testing.T) {
+	rates := NewFixedRates().WithRate(CurrencyEUR, CurrencyUSD, MustParse("1.0825"))
+	eur, err := NewMoney(MustParse("10.50"), CurrencyEUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usd, err := rates.Convert(eur, CurrencyUSD)
+	if err != nil || usd.String() != "11.37 USD" {
+		t.Errorf("Convert() = %s, %v, want 11.37 USD", usd, err)
+	}
+	if same, err := rates.Convert(eur, CurrencyEUR); err != nil || !same.Equal(eur) {
+		t.Errorf("Convert() to the same currency = %s, %v", same, err)
+	}
+	if _, err := rates.Convert(usd, CurrencyEUR); !errors.Is(err, ErrNoRate) {
+		t.Errorf("Convert() without inverse rate error = %v, want ErrNoRate", err)
+	}
+	if _, ok := NewFixedRates().Rate(CurrencyEUR, CurrencyUSD); ok {
+		t.Error("WithRate should not modify the original table")
+	}
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	r, err := NewCurrencyRegistry(CurrencyInfo{Code: "JPY", MinorUnits: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(CurrencyInfo{Code: "JPY"}); err == nil {
+		t.Error("Register() of a duplicate code should fail")
+	}
+	if err := r.Register(CurrencyInfo{Code: "BAD", MinorUnits: -1}); err == nil {
+		t.Error("Register() with negative minor units should fail")
+	}
+	info, ok := r.Lookup("JPY")
+	if !ok || info.Name != "JPY" || info.Quantum().String() != "1" {
+		t.Errorf("Lookup(JPY) = %+v, %v", info, ok)
+	}
+
+	for _, c := range []Currency{CurrencyUSD, CurrencyUSDC, CurrencyEUR, CurrencyGBP} {
+		if !c.IsValid() {
+			t.Errorf("%s is not in the default registry", c)
+		}
+	}
+	if info, _ := LookupCurrency(CurrencyUSDC); info.Quantum().String() != "0.000001" {
+		t.Errorf("USDC quantum = %s", info.Quantum())
+	}
+	if _, err := NewMoney(MustParse("1.5"), "CHF"); err == nil {
+		t.Error("NewMoney() in an unregistered currency should fail")
+	}
+	if err := r.Register(CurrencyInfo{Code: "CHF", Name: "Swiss Franc", MinorUnits: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.IsValid("CHF") || IsValidCurrency("CHF") {
+		t.Error("Register() should add CHF to its own registry only")
+	}
+	if codes := r.Codes(); !reflect.DeepEqual(codes, []Currency{"JPY", "CHF"}) {
+		t.Errorf("Codes() = %v, want registration order", codes)
+	}
+}
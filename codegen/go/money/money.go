@@ -0,0 +1,171 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCurrencyMismatch is returned when combining amounts in different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// PrecisionError reports an amount with more decimal places than its
+// currency allows, such as a sub-cent USD value
+type PrecisionError struct {
+	Amount   Decimal
+	Currency Currency
+	Places   int32
+}
+
+func (e *PrecisionError) Error() string {
+	return fmt.Sprintf("%s %s has more than %d decimal places", e.Amount, e.Currency, e.Places)
+}
+
+// Money is an exact amount in a currency. The amount never has more decimal
+// places than the currency allows. The zero value is 0 USD.
+type Money struct {
+	amount   Decimal
+	currency Currency
+}
+
+// NewMoney returns amount in currency, defaulting to USD when currency is
+// empty. Amounts with more decimal places than the currency allows are
+// rejected with a *PrecisionError.
+func NewMoney(amount Decimal, currency Currency) (Money, error) {
+	if currency == "" {
+		currency = CurrencyUSD
+	}
+	places, ok := currency.MinorUnits()
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", currency)
+	}
+	if amount.Places() > places {
+		return Money{}, &PrecisionError{Amount: amount, Currency: currency, Places: places}
+	}
+	return Money{amount: amount.Round(places), currency: currency}, nil
+}
+
+// USD returns amount in USD, rejecting sub-cent amounts
+func USD(amount Decimal) (Money, error) {
+	return NewMoney(amount, CurrencyUSD)
+}
+
+// USDFromFloat returns a float64 USD schema field as Money, rejecting
+// sub-cent amounts exactly
+func USDFromFloat(f float64) (Money, error) {
+	d, err := FromFloat(f)
+	if err != nil {
+		return Money{}, err
+	}
+	return USD(d)
+}
+
+// MustUSD is like USD for a literal amount and panics on invalid input
+func MustUSD(amount string) Money {
+	m, err := USD(MustParse(amount))
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Amount returns the amount, at the currency's scale
+func (m Money) Amount() Decimal {
+	if m.amount.coef == nil {
+		return Decimal{scale: m.places()}
+	}
+	return m.amount
+}
+
+// Currency returns the currency
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return CurrencyUSD
+	}
+	return m.currency
+}
+
+func (m Money) places() int32 {
+	places, _ := m.Currency().MinorUnits()
+	return places
+}
+
+// Add returns m + o. Both must be in the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency() != o.Currency() {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.Currency(), o.Currency())
+	}
+	return Money{amount: m.Amount().Add(o.Amount()), currency: m.Currency()}, nil
+}
+
+// Sub returns m - o. Both must be in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency() != o.Currency() {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrCurrencyMismatch, m.Currency(), o.Currency())
+	}
+	return Money{amount: m.Amount().Sub(o.Amount()), currency: m.Currency()}, nil
+}
+
+// Mul returns m × factor rounded half away from zero to the currency's
+// minor units, e.g. a size × price notional
+func (m Money) Mul(factor Decimal) Money {
+	return Money{amount: m.Amount().Mul(factor).Round(m.places()), currency: m.Currency()}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{amount: m.Amount().Neg(), currency: m.Currency()}
+}
+
+// Cmp compares m and o, which must be in the same currency
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency() != o.Currency() {
+		return 0, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency(), o.Currency())
+	}
+	return m.Amount().Cmp(o.Amount()), nil
+}
+
+// Equal reports whether m and o have the same currency and amount
+func (m Money) Equal(o Money) bool {
+	return m.Currency() == o.Currency() && m.Amount().Equal(o.Amount())
+}
+
+// IsZero reports whether the amount is 0
+func (m Money) IsZero() bool {
+	return m.amount.IsZero()
+}
+
+// Sign returns -1, 0 or +1
+func (m Money) Sign() int {
+	return m.amount.Sign()
+}
+
+// Float64 returns the amount as the float64 the schema fields hold
+func (m Money) Float64() float64 {
+	return m.amount.Float64()
+}
+
+// String returns the amount and currency, e.g. "12.50 USD"
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Amount(), m.Currency())
+}
+
+// MarshalJSON writes the amount as a JSON number, like the *_usd schema
+// fields; the currency is carried separately by the schemas
+func (m Money) MarshalJSON() ([]byte, error) {
+	return m.Amount().MarshalJSON()
+}
+
+// UnmarshalJSON reads a JSON number as USD, rejecting sub-cent amounts.
+// Set the currency first, e.g. with NewMoney, to read another currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount Decimal
+	if err := amount.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	parsed, err := NewMoney(amount, m.Currency())
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
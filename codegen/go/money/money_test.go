@@ -0,0 +1,76 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewMoney_Precision(t *testing.T) {
+	for _, f := range []float64{0.005, 100.001, 0.333} {
+		_, err := USDFromFloat(f)
+		var perr *PrecisionError
+		if !errors.As(err, &perr) || perr.Places != 2 {
+			t.Errorf("USDFromFloat(%v) error = %v, want *PrecisionError", f, err)
+		}
+	}
+	for _, f := range []float64{0, 0.01, 0.1, 125000.75, 1e9} {
+		if _, err := USDFromFloat(f); err != nil {
+			t.Errorf("USDFromFloat(%v) error = %v", f, err)
+		}
+	}
+	// Trailing zeros are not extra precision
+	if _, err := USD(MustParse("1.5000")); err != nil {
+		t.Errorf("USD(1.5000) error = %v", err)
+	}
+	if _, err := NewMoney(NewFromInt(1), "XXX"); err == nil {
+		t.Error("NewMoney with an unknown currency should fail")
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a, b := MustUSD("0.10"), MustUSD("0.20")
+	sum, err := a.Add(b)
+	if err != nil || !sum.Equal(MustUSD("0.30")) {
+		t.Errorf("0.10 + 0.20 = %s, %v", sum, err)
+	}
+	if got := MustUSD("0.57").Mul(MustParse("1.5")); got.String() != "0.86 USD" {
+		t.Errorf("0.57 × 1.5 = %s, want 0.86 USD", got)
+	}
+	if got := MustUSD("12.5"); got.String() != "12.50 USD" {
+		t.Errorf("String() = %s", got)
+	}
+	if (Money{}).String() != "0.00 USD" {
+		t.Errorf("zero Money = %s", Money{})
+	}
+
+	other := Money{amount: NewFromInt(1), currency: "EUR"}
+	if _, err := a.Add(other); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies error = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := a.Cmp(other); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp across currencies error = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var v struct {
+		Volume Money `json:"volume_24h_usd"`
+	}
+	if err := json.Unmarshal([]byte(`{"volume_24h_usd": 125000.75}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Volume.Currency() != CurrencyUSD || !v.Volume.Equal(MustUSD("125000.75")) {
+		t.Errorf("Unmarshal = %s", v.Volume)
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"volume_24h_usd":125000.75}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+
+	err = json.Unmarshal([]byte(`{"volume_24h_usd": 0.005}`), &v)
+	var perr *PrecisionError
+	if !errors.As(err, &perr) {
+		t.Errorf("Unmarshal of a sub-cent amount error = %v, want *PrecisionError", err)
+	}
+}
@@ -0,0 +1,53 @@
+package sundayschemas
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/money"
+)
+
+func TestUSDNotional(t *testing.T) {
+	tests := []struct {
+		prob, size, contractSize float64
+		want                     float64
+	}{
+		{0.285, 3, 1, 0.86},
+		{0.57, 150, 1, 85.5},
+		{0.1, 3, 1, 0.3},
+		{0.005, 1, 1, 0.01},
+		{0.42, 7, 0.01, 0.03},
+	}
+	for _, tt := range tests {
+		got, err := usdNotional(tt.prob, tt.size, tt.contractSize)
+		if err != nil || got != tt.want {
+			t.Errorf("usdNotional(%v, %v, %v) = %v, %v, want %v", tt.prob, tt.size, tt.contractSize, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizedTradeV1_NotionalAmount(t *testing.T) {
+	notional := 85.5
+	trade := NormalizedTradeV1{Prob: 0.57, Size: 150, NotionalUsd: &notional}
+	amount, ok, err := trade.NotionalAmount()
+	if err != nil || !ok || amount.String() != "85.50 USD" {
+		t.Errorf("NotionalAmount() = %s, %v, %v", amount, ok, err)
+	}
+
+	notional = 0.005
+	_, _, err = trade.NotionalAmount()
+	var perr *money.PrecisionError
+	if !errors.As(err, &perr) {
+		t.Errorf("NotionalAmount() error = %v, want *money.PrecisionError", err)
+	}
+
+	trade.NotionalUsd = nil
+	if _, ok, err := trade.NotionalAmount(); ok || err != nil {
+		t.Errorf("NotionalAmount() without notional = %v, %v", ok, err)
+	}
+
+	prob, err := trade.ProbDecimal()
+	if err != nil || !prob.Equal(money.MustParse("0.57")) {
+		t.Errorf("ProbDecimal() = %s, %v", prob, err)
+	}
+}
@@ -0,0 +1,498 @@
+// Package sundayschemas provides the reference raw.v0 normalizer
+//
+// The normalizer implements the rules in docs/mapping.md so that every
+// consumer replaying raw venue data derives the same md.* events.
+package sundayschemas
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+)
+
+// probabilityDecimals is the maximum precision of normalized probabilities (docs/mapping.md)
+const probabilityDecimals = 6
+
+// RejectReason classifies why a raw envelope could not be normalized
+type RejectReason string
+
+const (
+	RejectWrongStream       RejectReason = "wrong_stream"
+	RejectUnsupportedVenue  RejectReason = "unsupported_venue"
+	RejectUnknownInstrument RejectReason = "unknown_instrument"
+	RejectMalformedPayload  RejectReason = "malformed_payload"
+	RejectInvalidSide       RejectReason = "invalid_side"
+	RejectPriceOutOfRange   RejectReason = "price_out_of_range"
+	RejectInvalidSize       RejectReason = "invalid_size"
+	RejectInvalidTimestamp  RejectReason = "invalid_timestamp"
+	// RejectUnknownToken means a per-token book's asset_id is neither of the
+	// instrument's outcome tokens, so its side cannot be told
+	RejectUnknownToken RejectReason = "unknown_token"
+	// RejectNeedsSnapshot means the normalizer's book state is missing or
+	// inconsistent and the venue must be resubscribed for a fresh snapshot
+	RejectNeedsSnapshot RejectReason = "needs_snapshot"
+)
+
+// RejectionError reports a raw envelope the normalizer dropped
+type RejectionError struct {
+	Reason           RejectReason
+	Venue            VenueID
+	Stream           RawEnvelopeV0Stream
+	InstrumentNative string
+	Detail           string
+	Err              error
+}
+
+func (e *RejectionError) Error() string {
+	msg := fmt.Sprintf("rejected %s %s %q: %s", e.Venue, e.Stream, e.InstrumentNative, e.Reason)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *RejectionError) Unwrap() error {
+	return e.Err
+}
+
+// Normalizer converts raw.v0 envelopes into normalized market data using an
+// instrument registry to resolve canonical instrument IDs. It is safe for
+// concurrent use.
+type Normalizer struct {
+	instruments *InstrumentRegistry
+
+	mu sync.Mutex
+	// seqs holds the last seq emitted per (venue, instrument)
+	seqs map[bookKey]int64
+	// kalshiBooks holds native Kalshi ladders, needed to turn quantity
+	// deltas into absolute level sizes
+	kalshiBooks map[bookKey]*kalshiBook
+}
+
+type bookKey struct {
+	venue        VenueID
+	instrumentID string
+}
+
+// NewNormalizer creates a normalizer resolving instruments through the given registry
+func NewNormalizer(instruments *InstrumentRegistry) *Normalizer {
+	if instruments == nil {
+		instruments = &InstrumentRegistry{}
+	}
+	return &Normalizer{
+		instruments: instruments,
+		seqs:        make(map[bookKey]int64),
+		kalshiBooks: make(map[bookKey]*kalshiBook),
+	}
+}
+
+func reject(env *RawEnvelopeV0, reason RejectReason, detail string, err error) *RejectionError {
+	return &RejectionError{
+		Reason:           reason,
+		Venue:            env.VenueID,
+		Stream:           env.Stream,
+		InstrumentNative: env.InstrumentNative,
+		Detail:           detail,
+		Err:              err,
+	}
+}
+
+// resolve checks the envelope's stream and timestamp and returns the venue
+// descriptor and canonical instrument ID
+func (n *Normalizer) resolve(env *RawEnvelopeV0, stream RawEnvelopeV0Stream) (venues.Descriptor, string, error) {
+	if env.Stream != stream {
+		return venues.Descriptor{}, "", reject(env, RejectWrongStream, fmt.Sprintf("expected %s", stream), nil)
+	}
+	descriptor, ok := venues.Lookup(string(env.VenueID))
+	if !ok {
+		return venues.Descriptor{}, "", reject(env, RejectUnsupportedVenue, "", nil)
+	}
+	if env.TsEventMS < 0 {
+		return venues.Descriptor{}, "", reject(env, RejectInvalidTimestamp, "ts_event_ms must be >= 0", nil)
+	}
+	instrumentID, ok := n.instruments.Canonical(env.VenueID, env.InstrumentNative)
+	if !ok {
+		return venues.Descriptor{}, "", reject(env, RejectUnknownInstrument, "", nil)
+	}
+	return descriptor, instrumentID, nil
+}
+
+// nativeTrade is a venue trade reduced to YES-side terms
+type nativeTrade struct {
+	side Direction
+	// price is the native price of the traded outcome, before inversion
+	price float64
+	size  float64
+	// no is set when the NO outcome was traded
+	no bool
+}
+
+// NormalizeTrade converts a raw.v0 trades envelope into md.trade.v1:
+//
+//   - instrument_native is resolved to instrument_id through the registry
+//   - native prices are converted to probabilities using the venue's price unit
+//     (Kalshi cents / 100, Polymarket as-is)
+//   - trades of the NO outcome are expressed as the opposite side of YES at
+//     1 - price, so a Kalshi "no" at 37 cents becomes a sell at 0.63
+//   - notional_usd is the native price paid times size times the venue's contract size
+//
+// Envelopes that cannot be normalized are reported as *RejectionError.
+func (n *Normalizer) NormalizeTrade(env RawEnvelopeV0) (NormalizedTradeV1, error) {
+	descriptor, instrumentID, err := n.resolve(&env, Trades)
+	if err != nil {
+		return NormalizedTradeV1{}, err
+	}
+
+	payload, err := env.TypedPayload()
+	if err != nil {
+		var unsupported UnsupportedPayloadError
+		if errors.As(err, &unsupported) {
+			return NormalizedTradeV1{}, reject(&env, RejectUnsupportedVenue, "", err)
+		}
+		return NormalizedTradeV1{}, reject(&env, RejectMalformedPayload, "", err)
+	}
+
+	var trade nativeTrade
+	switch p := payload.(type) {
+	case KalshiTradePayload:
+		trade, err = kalshiTrade(p)
+	case PolymarketTradePayload:
+		trade, err = polymarketTrade(p)
+	default:
+		return NormalizedTradeV1{}, reject(&env, RejectUnsupportedVenue, fmt.Sprintf("no trade mapping for %T", payload), nil)
+	}
+	if err != nil {
+		return NormalizedTradeV1{}, withEnvelope(err, &env)
+	}
+
+	if trade.size <= 0 || math.IsNaN(trade.size) || math.IsInf(trade.size, 0) {
+		return NormalizedTradeV1{}, reject(&env, RejectInvalidSize, fmt.Sprintf("size %v", trade.size), nil)
+	}
+
+	prob, err := descriptor.ToProbability(trade.price)
+	if err != nil {
+		return NormalizedTradeV1{}, reject(&env, RejectUnsupportedVenue, "", err)
+	}
+	if !(prob >= 0 && prob <= 1) {
+		return NormalizedTradeV1{}, reject(&env, RejectPriceOutOfRange, fmt.Sprintf("price %v", trade.price), nil)
+	}
+	notional, err := usdNotional(prob, trade.size, descriptor.ContractSize)
+	if err != nil {
+		return NormalizedTradeV1{}, reject(&env, RejectInvalidSize, "", err)
+	}
+	if trade.no {
+		prob = 1 - prob
+	}
+
+	return NormalizedTradeV1{
+		Schema:       MdTradeV1,
+		InstrumentID: instrumentID,
+		VenueID:      env.VenueID,
+		TsMS:         env.TsEventMS,
+		Side:         trade.side,
+		Prob:         roundTo(prob, probabilityDecimals),
+		Size:         trade.size,
+		NotionalUsd:  &notional,
+	}, nil
+}
+
+func kalshiTrade(p KalshiTradePayload) (nativeTrade, error) {
+	trade := nativeTrade{price: float64(p.PriceCents), size: float64(p.Count)}
+	switch p.Side {
+	case "yes":
+		trade.side = Buy
+	case "no":
+		trade.side = Sell
+		trade.no = true
+	default:
+		return nativeTrade{}, &RejectionError{Reason: RejectInvalidSide, Detail: fmt.Sprintf("side %q", p.Side)}
+	}
+	return trade, nil
+}
+
+func polymarketTrade(p PolymarketTradePayload) (nativeTrade, error) {
+	price, size, err := PolymarketLevel{Price: p.Price, Size: p.Size}.Float64s()
+	if err != nil {
+		return nativeTrade{}, &RejectionError{Reason: RejectMalformedPayload, Err: err}
+	}
+	trade := nativeTrade{price: price, size: size}
+
+	switch strings.ToLower(p.Side) {
+	case "buy":
+		trade.side = Buy
+	case "sell":
+		trade.side = Sell
+	default:
+		return nativeTrade{}, &RejectionError{Reason: RejectInvalidSide, Detail: fmt.Sprintf("side %q", p.Side)}
+	}
+
+	switch strings.ToLower(p.Outcome) {
+	case "", "yes":
+	case "no":
+		trade.no = true
+		trade.side = oppositeDirection(trade.side)
+	default:
+		return nativeTrade{}, &RejectionError{Reason: RejectMalformedPayload, Detail: fmt.Sprintf("outcome %q", p.Outcome)}
+	}
+	return trade, nil
+}
+
+func oppositeDirection(d Direction) Direction {
+	if d == Buy {
+		return Sell
+	}
+	return Buy
+}
+
+// withEnvelope fills the envelope fields of a rejection built without one
+func withEnvelope(err error, env *RawEnvelopeV0) error {
+	if rej, ok := err.(*RejectionError); ok {
+		rej.Venue, rej.Stream, rej.InstrumentNative = env.VenueID, env.Stream, env.InstrumentNative
+	}
+	return err
+}
+
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
+
+// Polymarket orderbook event types. "book" messages carry the full book for a
+// token; the others carry absolute sizes for changed levels.
+const (
+	PolymarketBookSnapshot    = "book"
+	PolymarketBookUpdate      = "book_update"
+	PolymarketBookPriceChange = "price_change"
+)
+
+// Kalshi orderbook event types
+const (
+	KalshiOrderbookSnapshot = "orderbook_snapshot"
+	KalshiOrderbookDelta    = "orderbook_delta"
+)
+
+// kalshiBook is a native Kalshi book: quantity by price in cents for the YES
+// and NO bid ladders, plus the last venue seq applied
+type kalshiBook struct {
+	yes map[int64]int64
+	no  map[int64]int64
+	seq int64
+}
+
+// NormalizeOrderbook converts a raw.v0 orderbook envelope into md.orderbook.delta.v1:
+//
+//   - prices are converted to probabilities using the venue's price unit
+//   - Kalshi NO bids at p become YES asks at 1 - p, and Kalshi quantity deltas
+//     become absolute level sizes (0 removes the level)
+//   - Polymarket books are per token: the asset_id is resolved against the
+//     instrument's outcome tokens, and a NO-token book's bids at p become YES
+//     asks at 1 - p and its asks YES bids. A book without asset_id is taken
+//     to be the YES book.
+//   - is_snapshot is set for full-book messages
+//   - seq increases by one per emitted message for each (venue, instrument)
+//
+// Bids are sorted by descending price and asks by ascending price. Envelopes
+// that cannot be normalized are reported as *RejectionError and do not consume
+// a seq; RejectNeedsSnapshot means the instrument must be resubscribed.
+func (n *Normalizer) NormalizeOrderbook(env RawEnvelopeV0) (NormalizedOrderBookDeltaV1, error) {
+	descriptor, instrumentID, err := n.resolve(&env, Orderbook)
+	if err != nil {
+		return NormalizedOrderBookDeltaV1{}, err
+	}
+
+	payload, err := env.TypedPayload()
+	if err != nil {
+		var unsupported UnsupportedPayloadError
+		if errors.As(err, &unsupported) {
+			return NormalizedOrderBookDeltaV1{}, reject(&env, RejectUnsupportedVenue, "", err)
+		}
+		return NormalizedOrderBookDeltaV1{}, reject(&env, RejectMalformedPayload, "", err)
+	}
+
+	key := bookKey{env.VenueID, instrumentID}
+	out := NormalizedOrderBookDeltaV1{
+		Schema:       MdOrderbookDeltaV1,
+		InstrumentID: instrumentID,
+		VenueID:      env.VenueID,
+		TsMS:         env.TsEventMS,
+		Bids:         []PriceLevel{},
+		Asks:         []PriceLevel{},
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	switch p := payload.(type) {
+	case KalshiOrderbookPayload:
+		err = n.kalshiOrderbook(key, descriptor, p, &out)
+	case PolymarketOrderbookPayload:
+		err = n.polymarketOrderbook(p, &out)
+	default:
+		return NormalizedOrderBookDeltaV1{}, reject(&env, RejectUnsupportedVenue, fmt.Sprintf("no orderbook mapping for %T", payload), nil)
+	}
+	if err != nil {
+		return NormalizedOrderBookDeltaV1{}, withEnvelope(err, &env)
+	}
+
+	SortBids(out.Bids)
+	SortAsks(out.Asks)
+
+	n.seqs[key]++
+	out.Seq = n.seqs[key]
+	return out, nil
+}
+
+func (n *Normalizer) polymarketOrderbook(p PolymarketOrderbookPayload, out *NormalizedOrderBookDeltaV1) error {
+	switch p.EventType {
+	case PolymarketBookSnapshot:
+		out.IsSnapshot = true
+	case PolymarketBookUpdate, PolymarketBookPriceChange:
+	default:
+		return &RejectionError{Reason: RejectMalformedPayload, Detail: fmt.Sprintf("event_type %q", p.EventType)}
+	}
+
+	no := false
+	if p.AssetID != "" {
+		outcome, ok := n.instruments.TokenOutcome(out.InstrumentID, out.VenueID, p.AssetID)
+		if !ok {
+			return &RejectionError{Reason: RejectUnknownToken, Detail: fmt.Sprintf("asset_id %q", p.AssetID)}
+		}
+		no = outcome == OutcomeNo
+	}
+
+	bids, asks := &out.Bids, &out.Asks
+	if no {
+		bids, asks = asks, bids
+	}
+	for _, side := range []struct {
+		levels []PolymarketLevel
+		dst    *[]PriceLevel
+	}{{p.Bids, bids}, {p.Asks, asks}} {
+		for _, level := range side.levels {
+			price, size, err := level.Float64s()
+			if err != nil {
+				return &RejectionError{Reason: RejectMalformedPayload, Err: err}
+			}
+			if err := checkLevel(price, size); err != nil {
+				return err
+			}
+			if no {
+				price = 1 - price
+			}
+			*side.dst = append(*side.dst, PriceLevel{Price: roundTo(price, probabilityDecimals), Size: size})
+		}
+	}
+	return nil
+}
+
+func (n *Normalizer) kalshiOrderbook(key bookKey, descriptor venues.Descriptor, p KalshiOrderbookPayload, out *NormalizedOrderBookDeltaV1) error {
+	book := n.kalshiBooks[key]
+
+	switch p.EventType {
+	case KalshiOrderbookSnapshot:
+		book = &kalshiBook{yes: make(map[int64]int64), no: make(map[int64]int64), seq: p.Seq}
+		for _, level := range p.Yes {
+			book.yes[level[0]] = level[1]
+		}
+		for _, level := range p.No {
+			book.no[level[0]] = level[1]
+		}
+		if err := kalshiLevels(descriptor, book.yes, false, &out.Bids); err != nil {
+			delete(n.kalshiBooks, key)
+			return err
+		}
+		if err := kalshiLevels(descriptor, book.no, true, &out.Asks); err != nil {
+			delete(n.kalshiBooks, key)
+			return err
+		}
+		n.kalshiBooks[key] = book
+		out.IsSnapshot = true
+		return nil
+
+	case KalshiOrderbookDelta:
+		if book == nil {
+			return &RejectionError{Reason: RejectNeedsSnapshot, Detail: "delta before snapshot"}
+		}
+		if p.Seq != 0 && book.seq != 0 && p.Seq != book.seq+1 {
+			delete(n.kalshiBooks, key)
+			return &RejectionError{Reason: RejectNeedsSnapshot, Detail: fmt.Sprintf("venue seq %d after %d", p.Seq, book.seq)}
+		}
+
+		ladder, dst, no := book.yes, &out.Bids, false
+		switch p.Side {
+		case "yes":
+		case "no":
+			ladder, dst, no = book.no, &out.Asks, true
+		default:
+			return &RejectionError{Reason: RejectInvalidSide, Detail: fmt.Sprintf("side %q", p.Side)}
+		}
+
+		qty := ladder[p.Price] + p.Delta
+		if qty < 0 {
+			delete(n.kalshiBooks, key)
+			return &RejectionError{Reason: RejectNeedsSnapshot, Detail: fmt.Sprintf("negative quantity at %d", p.Price)}
+		}
+		level, err := kalshiLevel(descriptor, p.Price, qty, no)
+		if err != nil {
+			return err
+		}
+
+		if qty == 0 {
+			delete(ladder, p.Price)
+		} else {
+			ladder[p.Price] = qty
+		}
+		if p.Seq != 0 {
+			book.seq = p.Seq
+		}
+		*dst = append(*dst, level)
+		return nil
+
+	default:
+		return &RejectionError{Reason: RejectMalformedPayload, Detail: fmt.Sprintf("event_type %q", p.EventType)}
+	}
+}
+
+// kalshiLevels converts cent-priced levels to probability levels, inverting
+// NO bids into YES asks
+func kalshiLevels(descriptor venues.Descriptor, levels map[int64]int64, no bool, dst *[]PriceLevel) error {
+	for cents, qty := range levels {
+		level, err := kalshiLevel(descriptor, cents, qty, no)
+		if err != nil {
+			return err
+		}
+		*dst = append(*dst, level)
+	}
+	return nil
+}
+
+func kalshiLevel(descriptor venues.Descriptor, cents, qty int64, no bool) (PriceLevel, error) {
+	prob, err := descriptor.ToProbability(float64(cents))
+	if err != nil {
+		return PriceLevel{}, &RejectionError{Reason: RejectUnsupportedVenue, Err: err}
+	}
+	if err := checkLevel(prob, float64(qty)); err != nil {
+		return PriceLevel{}, err
+	}
+	if no {
+		prob = 1 - prob
+	}
+	return PriceLevel{Price: roundTo(prob, probabilityDecimals), Size: float64(qty)}, nil
+}
+
+func checkLevel(prob, size float64) error {
+	if !(prob >= 0 && prob <= 1) {
+		return &RejectionError{Reason: RejectPriceOutOfRange, Detail: fmt.Sprintf("price %v", prob)}
+	}
+	if !(size >= 0) || math.IsInf(size, 0) {
+		return &RejectionError{Reason: RejectInvalidSize, Detail: fmt.Sprintf("size %v", size)}
+	}
+	return nil
+}
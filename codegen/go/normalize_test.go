@@ -0,0 +1,297 @@
+package sundayschemas
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testNormalizer(t *testing.T) *Normalizer {
+	t.Helper()
+	instruments, err := NewInstrumentRegistry(Instrument{
+		ID:      "pm_us_election_2028_winner",
+		Natives: map[VenueID]string{VenueKalshi: "PRES-28", VenuePolymarket: "0x12345abcdef..."},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewNormalizer(instruments)
+}
+
+func TestNormalizer_NormalizeTrade_KalshiExample(t *testing.T) {
+	env, err := UnmarshalRawEnvelopeV0(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "raw.kalshi.trade.example.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trade, err := testNormalizer(t).NormalizeTrade(env)
+	if err != nil {
+		t.Fatalf("NormalizeTrade() error = %v", err)
+	}
+	if trade.InstrumentID != "pm_us_election_2028_winner" || trade.Side != Buy || trade.Prob != 0.63 || trade.Size != 100 || trade.TsMS != 1758763048456 {
+		t.Errorf("NormalizeTrade() = %+v", trade)
+	}
+	if trade.NotionalUsd == nil || *trade.NotionalUsd != 63 {
+		t.Errorf("NotionalUsd = %v, want 63", trade.NotionalUsd)
+	}
+
+	data, err := trade.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateJSON(SchemaMD_TRADE_V1, data); err != nil {
+		t.Errorf("normalized trade fails schema validation: %v", err)
+	}
+}
+
+func TestNormalizer_NormalizeTrade_Conversions(t *testing.T) {
+	tests := []struct {
+		name     string
+		venue    VenueID
+		native   string
+		payload  map[string]interface{}
+		side     Direction
+		prob     float64
+		size     float64
+		notional float64
+	}{
+		{
+			name: "kalshi no inverts", venue: VenueKalshi, native: "PRES-28",
+			payload: map[string]interface{}{"side": "no", "price_cents": 37.0, "count": 10.0},
+			side:    Sell, prob: 0.63, size: 10, notional: 3.7,
+		},
+		{
+			name: "polymarket yes buy", venue: VenuePolymarket, native: "0x12345abcdef...",
+			payload: map[string]interface{}{"side": "BUY", "outcome": "Yes", "price": "0.42", "size": "250"},
+			side:    Buy, prob: 0.42, size: 250, notional: 105,
+		},
+		{
+			name: "polymarket no buy is yes sell", venue: VenuePolymarket, native: "0x12345abcdef...",
+			payload: map[string]interface{}{"side": "BUY", "outcome": "No", "price": "0.3", "size": "10"},
+			side:    Sell, prob: 0.7, size: 10, notional: 3,
+		},
+	}
+
+	normalizer := testNormalizer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := RawEnvelopeV0{Schema: RawV0, VenueID: tt.venue, Stream: Trades, InstrumentNative: tt.native, TsEventMS: 1, Payload: tt.payload}
+			trade, err := normalizer.NormalizeTrade(env)
+			if err != nil {
+				t.Fatalf("NormalizeTrade() error = %v", err)
+			}
+			if trade.Side != tt.side || trade.Prob != tt.prob || trade.Size != tt.size || *trade.NotionalUsd != tt.notional {
+				t.Errorf("NormalizeTrade() = side %s prob %v size %v notional %v", trade.Side, trade.Prob, trade.Size, *trade.NotionalUsd)
+			}
+		})
+	}
+}
+
+func TestNormalizer_NormalizeTrade_Rejections(t *testing.T) {
+	valid := map[string]interface{}{"side": "yes", "price_cents": 50.0, "count": 1.0}
+	tests := []struct {
+		name   string
+		env    RawEnvelopeV0
+		reason RejectReason
+	}{
+		{"wrong stream", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Orderbook, InstrumentNative: "PRES-28", Payload: valid}, RejectWrongStream},
+		{"unknown venue", RawEnvelopeV0{VenueID: "manifold", Stream: Trades, InstrumentNative: "PRES-28", Payload: valid}, RejectUnsupportedVenue},
+		{"unknown instrument", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "FEDR-25DEC", Payload: valid}, RejectUnknownInstrument},
+		{"negative timestamp", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "PRES-28", TsEventMS: -1, Payload: valid}, RejectInvalidTimestamp},
+		{"malformed payload", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "PRES-28", Payload: map[string]interface{}{"count": "many"}}, RejectMalformedPayload},
+		{"invalid side", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "PRES-28", Payload: map[string]interface{}{"side": "maybe", "price_cents": 50.0}}, RejectInvalidSide},
+		{"price out of range", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "PRES-28", Payload: map[string]interface{}{"side": "yes", "price_cents": 150.0, "count": 1.0}}, RejectPriceOutOfRange},
+		{"negative size", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "PRES-28", Payload: map[string]interface{}{"side": "yes", "price_cents": 50.0, "count": -3.0}}, RejectInvalidSize},
+		{"zero size", RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, InstrumentNative: "PRES-28", Payload: map[string]interface{}{"side": "yes", "price_cents": 50.0, "count": 0.0}}, RejectInvalidSize},
+		{"polymarket bad price", RawEnvelopeV0{VenueID: VenuePolymarket, Stream: Trades, InstrumentNative: "0x12345abcdef...", Payload: map[string]interface{}{"side": "BUY", "price": "abc", "size": "1"}}, RejectMalformedPayload},
+	}
+
+	normalizer := testNormalizer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := normalizer.NormalizeTrade(tt.env)
+			var rejection *RejectionError
+			if !errors.As(err, &rejection) {
+				t.Fatalf("NormalizeTrade() error = %v, want *RejectionError", err)
+			}
+			if rejection.Reason != tt.reason {
+				t.Errorf("Reason = %s, want %s", rejection.Reason, tt.reason)
+			}
+			if rejection.Venue != tt.env.VenueID || rejection.InstrumentNative != tt.env.InstrumentNative {
+				t.Errorf("rejection missing envelope context: %+v", rejection)
+			}
+		})
+	}
+}
+
+func TestNormalizer_NormalizeOrderbook_PolymarketExample(t *testing.T) {
+	env, err := UnmarshalRawEnvelopeV0(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "raw.polymarket.orderbook.example.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	normalizer := testNormalizer(t)
+	book, err := normalizer.NormalizeOrderbook(env)
+	if err != nil {
+		t.Fatalf("NormalizeOrderbook() error = %v", err)
+	}
+	want := NormalizedOrderBookDeltaV1{
+		Schema:       MdOrderbookDeltaV1,
+		InstrumentID: "pm_us_election_2028_winner",
+		VenueID:      VenuePolymarket,
+		Seq:          1,
+		TsMS:         1758763048123,
+		Bids:         []PriceLevel{{0.63, 1000}, {0.62, 500}},
+		Asks:         []PriceLevel{{0.64, 800}, {0.65, 1200}},
+	}
+	if !reflect.DeepEqual(book, want) {
+		t.Errorf("NormalizeOrderbook() = %+v, want %+v", book, want)
+	}
+
+	data, err := book.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateJSON(SchemaMD_ORDERBOOK_DELTA_V1, data); err != nil {
+		t.Errorf("normalized orderbook fails schema validation: %v", err)
+	}
+
+	env.Payload["event_type"] = "book"
+	if book, err = normalizer.NormalizeOrderbook(env); err != nil || !book.IsSnapshot || book.Seq != 2 {
+		t.Errorf("NormalizeOrderbook(book) = seq %d snapshot %v err %v, want seq 2 snapshot", book.Seq, book.IsSnapshot, err)
+	}
+}
+
+// Polymarket books each outcome token separately; a NO-token book is the YES
+// book seen from the other side
+func TestNormalizer_NormalizeOrderbook_PolymarketNoToken(t *testing.T) {
+	instruments, err := NewInstrumentRegistry(Instrument{
+		ID:      "pm_us_election_2028_winner",
+		Natives: map[VenueID]string{VenuePolymarket: "0x12345abcdef..."},
+		Tokens:  map[VenueID]OutcomeTokens{VenuePolymarket: {Yes: "yes-token", No: "no-token"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalizer := NewNormalizer(instruments)
+	book := func(asset string) RawEnvelopeV0 {
+		return RawEnvelopeV0{Schema: RawV0, VenueID: VenuePolymarket, Stream: Orderbook, InstrumentNative: "0x12345abcdef...", TsEventMS: 1, Payload: map[string]interface{}{
+			"event_type": "book", "asset_id": asset,
+			"bids": []interface{}{map[string]interface{}{"price": "0.36", "size": "800"}, map[string]interface{}{"price": "0.35", "size": "1200"}},
+			"asks": []interface{}{map[string]interface{}{"price": "0.37", "size": "1000"}},
+		}}
+	}
+
+	yes, err := normalizer.NormalizeOrderbook(book("yes-token"))
+	if err != nil || !reflect.DeepEqual(yes.Bids, []PriceLevel{{0.36, 800}, {0.35, 1200}}) || !reflect.DeepEqual(yes.Asks, []PriceLevel{{0.37, 1000}}) {
+		t.Errorf("YES book = %+v, %v", yes, err)
+	}
+	no, err := normalizer.NormalizeOrderbook(book("no-token"))
+	if err != nil {
+		t.Fatalf("NO book error = %v", err)
+	}
+	if !reflect.DeepEqual(no.Bids, []PriceLevel{{0.63, 1000}}) || !reflect.DeepEqual(no.Asks, []PriceLevel{{0.64, 800}, {0.65, 1200}}) {
+		t.Errorf("NO book bids = %v, asks = %v, want NO asks as YES bids at 1-p and NO bids as YES asks", no.Bids, no.Asks)
+	}
+	if no.Seq != yes.Seq+1 || !no.IsSnapshot {
+		t.Errorf("NO book seq = %d, snapshot = %v; want the instrument's next seq", no.Seq, no.IsSnapshot)
+	}
+
+	_, err = normalizer.NormalizeOrderbook(book("other-token"))
+	var rej *RejectionError
+	if !errors.As(err, &rej) || rej.Reason != RejectUnknownToken {
+		t.Errorf("unknown asset error = %v, want %s", err, RejectUnknownToken)
+	}
+	// Without tokens in the registry a book's side cannot be told
+	if _, err := testNormalizer(t).NormalizeOrderbook(book("no-token")); !errors.As(err, &rej) || rej.Reason != RejectUnknownToken {
+		t.Errorf("asset without registry tokens error = %v, want %s", err, RejectUnknownToken)
+	}
+}
+
+func TestNormalizer_NormalizeOrderbook_Kalshi(t *testing.T) {
+	normalizer := testNormalizer(t)
+	kalshi := func(payload map[string]interface{}) RawEnvelopeV0 {
+		return RawEnvelopeV0{Schema: RawV0, VenueID: VenueKalshi, Stream: Orderbook, InstrumentNative: "PRES-28", TsEventMS: 1, Payload: payload}
+	}
+
+	_, err := normalizer.NormalizeOrderbook(kalshi(map[string]interface{}{"event_type": "orderbook_delta", "price": 62.0, "delta": 5.0, "side": "yes"}))
+	assertRejected(t, err, RejectNeedsSnapshot)
+
+	snapshot, err := normalizer.NormalizeOrderbook(kalshi(map[string]interface{}{
+		"event_type": "orderbook_snapshot",
+		"seq":        10.0,
+		"yes":        []interface{}{[]interface{}{61.0, 100.0}, []interface{}{62.0, 200.0}},
+		"no":         []interface{}{[]interface{}{35.0, 50.0}, []interface{}{36.0, 80.0}},
+	}))
+	if err != nil {
+		t.Fatalf("NormalizeOrderbook(snapshot) error = %v", err)
+	}
+	if !snapshot.IsSnapshot || snapshot.Seq != 1 {
+		t.Errorf("snapshot IsSnapshot = %v, Seq = %d", snapshot.IsSnapshot, snapshot.Seq)
+	}
+	if want := []PriceLevel{{0.62, 200}, {0.61, 100}}; !reflect.DeepEqual(snapshot.Bids, want) {
+		t.Errorf("snapshot Bids = %v, want %v", snapshot.Bids, want)
+	}
+	if want := []PriceLevel{{0.64, 80}, {0.65, 50}}; !reflect.DeepEqual(snapshot.Asks, want) {
+		t.Errorf("snapshot Asks = %v, want NO bids inverted to %v", snapshot.Asks, want)
+	}
+
+	delta, err := normalizer.NormalizeOrderbook(kalshi(map[string]interface{}{"event_type": "orderbook_delta", "seq": 11.0, "price": 35.0, "delta": -50.0, "side": "no"}))
+	if err != nil {
+		t.Fatalf("NormalizeOrderbook(delta) error = %v", err)
+	}
+	if delta.IsSnapshot || delta.Seq != 2 || len(delta.Bids) != 0 || !reflect.DeepEqual(delta.Asks, []PriceLevel{{0.65, 0}}) {
+		t.Errorf("delta = %+v, want ask 0.65 removed at seq 2", delta)
+	}
+
+	delta, err = normalizer.NormalizeOrderbook(kalshi(map[string]interface{}{"event_type": "orderbook_delta", "seq": 12.0, "price": 62.0, "delta": 25.0, "side": "yes"}))
+	if err != nil || !reflect.DeepEqual(delta.Bids, []PriceLevel{{0.62, 225}}) || delta.Seq != 3 {
+		t.Errorf("delta = %+v, %v, want bid 0.62 size 225 at seq 3", delta, err)
+	}
+
+	_, err = normalizer.NormalizeOrderbook(kalshi(map[string]interface{}{"event_type": "orderbook_delta", "seq": 14.0, "price": 62.0, "delta": 1.0, "side": "yes"}))
+	assertRejected(t, err, RejectNeedsSnapshot)
+	_, err = normalizer.NormalizeOrderbook(kalshi(map[string]interface{}{"event_type": "orderbook_delta", "seq": 15.0, "price": 62.0, "delta": 1.0, "side": "yes"}))
+	assertRejected(t, err, RejectNeedsSnapshot)
+}
+
+func TestNormalizer_NormalizeOrderbook_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		venue   VenueID
+		native  string
+		payload map[string]interface{}
+		reason  RejectReason
+	}{
+		{"unknown event type", VenuePolymarket, "0x12345abcdef...", map[string]interface{}{"event_type": "tick"}, RejectMalformedPayload},
+		{"price above one", VenuePolymarket, "0x12345abcdef...", map[string]interface{}{"event_type": "book", "bids": []interface{}{map[string]interface{}{"price": "1.2", "size": "1"}}}, RejectPriceOutOfRange},
+		{"negative size", VenuePolymarket, "0x12345abcdef...", map[string]interface{}{"event_type": "book", "asks": []interface{}{map[string]interface{}{"price": "0.5", "size": "-1"}}}, RejectInvalidSize},
+		{"kalshi cents above 100", VenueKalshi, "PRES-28", map[string]interface{}{"event_type": "orderbook_snapshot", "yes": []interface{}{[]interface{}{120.0, 1.0}}}, RejectPriceOutOfRange},
+	}
+
+	normalizer := testNormalizer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := RawEnvelopeV0{Schema: RawV0, VenueID: tt.venue, Stream: Orderbook, InstrumentNative: tt.native, TsEventMS: 1, Payload: tt.payload}
+			_, err := normalizer.NormalizeOrderbook(env)
+			assertRejected(t, err, tt.reason)
+		})
+	}
+
+	ok := RawEnvelopeV0{Schema: RawV0, VenueID: VenuePolymarket, Stream: Orderbook, InstrumentNative: "0x12345abcdef...", TsEventMS: 1, Payload: map[string]interface{}{"event_type": "book"}}
+	if book, err := normalizer.NormalizeOrderbook(ok); err != nil || book.Seq != 1 {
+		t.Errorf("NormalizeOrderbook() = seq %d, %v; rejected messages must not consume seq", book.Seq, err)
+	}
+}
+
+func assertRejected(t *testing.T, err error, reason RejectReason) {
+	t.Helper()
+	var rejection *RejectionError
+	if !errors.As(err, &rejection) {
+		t.Fatalf("error = %v, want *RejectionError", err)
+	}
+	if rejection.Reason != reason {
+		t.Errorf("Reason = %s, want %s (%v)", rejection.Reason, reason, err)
+	}
+}
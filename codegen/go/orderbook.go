@@ -0,0 +1,280 @@
+// Package sundayschemas provides local order book reconstruction from md.orderbook.delta.v1
+package sundayschemas
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrBookNotSynced is returned when a delta arrives for a book that has no
+// snapshot, or whose sequence broke since the last snapshot
+var ErrBookNotSynced = errors.New("order book needs a snapshot")
+
+// SequenceError reports a delta whose seq does not follow the book's last seq.
+// A gap (Seq > LastSeq+1) or a seq that goes backwards (Seq < LastSeq, e.g.
+// after the producer restarted and its seq reset) invalidates the book; a
+// duplicate (Seq == LastSeq) is ignored and the book stays usable.
+type SequenceError struct {
+	Venue        VenueID
+	InstrumentID string
+	LastSeq      int64
+	Seq          int64
+}
+
+// Gap reports whether messages were missed
+func (e *SequenceError) Gap() bool {
+	return e.Seq > e.LastSeq+1
+}
+
+// Reset reports whether the seq went backwards
+func (e *SequenceError) Reset() bool {
+	return e.Seq < e.LastSeq
+}
+
+// NeedsResync reports whether the error invalidated the book
+func (e *SequenceError) NeedsResync() bool {
+	return e.Gap() || e.Reset()
+}
+
+func (e *SequenceError) Error() string {
+	switch {
+	case e.Gap():
+		return fmt.Sprintf("%s %s: sequence gap: seq %d after %d", e.Venue, e.InstrumentID, e.Seq, e.LastSeq)
+	case e.Reset():
+		return fmt.Sprintf("%s %s: sequence reset: seq %d after %d", e.Venue, e.InstrumentID, e.Seq, e.LastSeq)
+	default:
+		return fmt.Sprintf("%s %s: duplicate seq %d", e.Venue, e.InstrumentID, e.Seq)
+	}
+}
+
+// OrderBook is one instrument's book on one venue, rebuilt from
+// md.orderbook.delta.v1 messages. It is safe for concurrent use.
+type OrderBook struct {
+	venue        VenueID
+	instrumentID string
+
+	mu     sync.RWMutex
+	bids   map[float64]float64
+	asks   map[float64]float64
+	seq    int64
+	tsMS   int64
+	synced bool
+}
+
+// NewOrderBook creates an empty book awaiting its first snapshot
+func NewOrderBook(venue VenueID, instrumentID string) *OrderBook {
+	return &OrderBook{
+		venue:        venue,
+		instrumentID: instrumentID,
+		bids:         make(map[float64]float64),
+		asks:         make(map[float64]float64),
+	}
+}
+
+// Apply applies a snapshot or delta. Snapshots always replace the book, seq
+// included, and clear any resync condition, so a producer whose seq restarted
+// recovers with its next snapshot. Deltas set the size of each listed level,
+// and size 0 removes the level. Deltas are applied only when their seq is
+// exactly one past the book's; otherwise Apply returns *SequenceError (or
+// ErrBookNotSynced) and leaves the levels unchanged. Malformed levels reject
+// the whole message.
+func (b *OrderBook) Apply(delta NormalizedOrderBookDeltaV1) error {
+	if delta.VenueID != b.venue || delta.InstrumentID != b.instrumentID {
+		return fmt.Errorf("delta for %s %s applied to book %s %s", delta.VenueID, delta.InstrumentID, b.venue, b.instrumentID)
+	}
+	if err := ValidateLevels(delta.Bids); err != nil {
+		return fmt.Errorf("bids: %w", err)
+	}
+	if err := ValidateLevels(delta.Asks); err != nil {
+		return fmt.Errorf("asks: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if delta.IsSnapshot {
+		b.bids = make(map[float64]float64, len(delta.Bids))
+		b.asks = make(map[float64]float64, len(delta.Asks))
+		setLevels(b.bids, delta.Bids)
+		setLevels(b.asks, delta.Asks)
+		b.seq, b.tsMS, b.synced = delta.Seq, delta.TsMS, true
+		return nil
+	}
+
+	if !b.synced {
+		return ErrBookNotSynced
+	}
+	if delta.Seq != b.seq+1 {
+		err := &SequenceError{Venue: b.venue, InstrumentID: b.instrumentID, LastSeq: b.seq, Seq: delta.Seq}
+		if err.NeedsResync() {
+			b.synced = false
+		}
+		return err
+	}
+
+	setLevels(b.bids, delta.Bids)
+	setLevels(b.asks, delta.Asks)
+	b.seq, b.tsMS = delta.Seq, delta.TsMS
+	return nil
+}
+
+func setLevels(side map[float64]float64, levels []PriceLevel) {
+	for _, level := range levels {
+		if level.Size == 0 {
+			delete(side, level.Price)
+		} else {
+			side[level.Price] = level.Size
+		}
+	}
+}
+
+// NeedsResync reports whether the book must be reloaded from a snapshot
+func (b *OrderBook) NeedsResync() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return !b.synced
+}
+
+// Seq returns the seq of the last applied message
+func (b *OrderBook) Seq() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seq
+}
+
+// Bids returns the bid ladder sorted by descending price
+func (b *OrderBook) Bids() []PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return ladder(b.bids, true)
+}
+
+// Asks returns the ask ladder sorted by ascending price
+func (b *OrderBook) Asks() []PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return ladder(b.asks, false)
+}
+
+// BestBid returns the highest bid
+func (b *OrderBook) BestBid() (price, size float64, ok bool) {
+	return b.best(true)
+}
+
+// BestAsk returns the lowest ask
+func (b *OrderBook) BestAsk() (price, size float64, ok bool) {
+	return b.best(false)
+}
+
+func (b *OrderBook) best(bids bool) (float64, float64, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	side := b.asks
+	if bids {
+		side = b.bids
+	}
+	var price float64
+	found := false
+	for p := range side {
+		if !found || (bids && p > price) || (!bids && p < price) {
+			price, found = p, true
+		}
+	}
+	return price, side[price], found
+}
+
+// Snapshot returns the book as an md.orderbook.delta.v1 snapshot message
+func (b *OrderBook) Snapshot() NormalizedOrderBookDeltaV1 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return NormalizedOrderBookDeltaV1{
+		Schema:       MdOrderbookDeltaV1,
+		InstrumentID: b.instrumentID,
+		VenueID:      b.venue,
+		Seq:          b.seq,
+		TsMS:         b.tsMS,
+		Bids:         ladder(b.bids, true),
+		Asks:         ladder(b.asks, false),
+		IsSnapshot:   true,
+	}
+}
+
+func ladder(side map[float64]float64, descending bool) []PriceLevel {
+	out := make([]PriceLevel, 0, len(side))
+	for price, size := range side {
+		out = append(out, PriceLevel{Price: price, Size: size})
+	}
+	if descending {
+		SortBids(out)
+	} else {
+		SortAsks(out)
+	}
+	return out
+}
+
+// BookKey identifies an order book
+type BookKey struct {
+	Venue        VenueID
+	InstrumentID string
+}
+
+// OrderBookEngine maintains an OrderBook per (venue, instrument) from a
+// md.normalized.orderbook stream. It is safe for concurrent use.
+type OrderBookEngine struct {
+	mu    sync.RWMutex
+	books map[BookKey]*OrderBook
+}
+
+// NewOrderBookEngine creates an engine with no books
+func NewOrderBookEngine() *OrderBookEngine {
+	return &OrderBookEngine{books: make(map[BookKey]*OrderBook)}
+}
+
+// Apply routes a message to its book, creating the book on first sight.
+// Errors are those of OrderBook.Apply.
+func (e *OrderBookEngine) Apply(delta NormalizedOrderBookDeltaV1) error {
+	key := BookKey{Venue: delta.VenueID, InstrumentID: delta.InstrumentID}
+
+	e.mu.RLock()
+	book, ok := e.books[key]
+	e.mu.RUnlock()
+	if !ok {
+		e.mu.Lock()
+		if book, ok = e.books[key]; !ok {
+			book = NewOrderBook(key.Venue, key.InstrumentID)
+			e.books[key] = book
+		}
+		e.mu.Unlock()
+	}
+
+	return book.Apply(delta)
+}
+
+// Book returns the book for a venue and instrument
+func (e *OrderBookEngine) Book(venue VenueID, instrumentID string) (*OrderBook, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	book, ok := e.books[BookKey{Venue: venue, InstrumentID: instrumentID}]
+	return book, ok
+}
+
+// NeedsResync returns the books awaiting a snapshot, sorted by venue and instrument
+func (e *OrderBookEngine) NeedsResync() []BookKey {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var out []BookKey
+	for key, book := range e.books {
+		if book.NeedsResync() {
+			out = append(out, key)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Venue != out[j].Venue {
+			return out[i].Venue < out[j].Venue
+		}
+		return out[i].InstrumentID < out[j].InstrumentID
+	})
+	return out
+}
@@ -0,0 +1,199 @@
+package sundayschemas
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func bookDelta(seq int64, snapshot bool, bids, asks []PriceLevel) NormalizedOrderBookDeltaV1 {
+	return NormalizedOrderBookDeltaV1{
+		Schema:       MdOrderbookDeltaV1,
+		InstrumentID: "pm_us_election_2028_winner",
+		VenueID:      VenueKalshi,
+		Seq:          seq,
+		TsMS:         seq * 10,
+		Bids:         bids,
+		Asks:         asks,
+		IsSnapshot:   snapshot,
+	}
+}
+
+func TestOrderBook_SnapshotAndDeltas(t *testing.T) {
+	snapshot, err := UnmarshalNormalizedOrderBookDeltaV1(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "md.orderbook.snapshot.example.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book := NewOrderBook(VenueKalshi, "pm_us_election_2028_winner")
+	if err := book.Apply(bookDelta(1, false, nil, nil)); !errors.Is(err, ErrBookNotSynced) {
+		t.Fatalf("Apply(delta before snapshot) error = %v, want ErrBookNotSynced", err)
+	}
+	if err := book.Apply(snapshot); err != nil {
+		t.Fatalf("Apply(snapshot) error = %v", err)
+	}
+
+	delta := bookDelta(12351, false, []PriceLevel{{0.63, 50}, {0.62, 0}}, []PriceLevel{{0.64, 10}})
+	if err := book.Apply(delta); err != nil {
+		t.Fatalf("Apply(delta) error = %v", err)
+	}
+
+	if want := []PriceLevel{{0.63, 50}, {0.61, 1500}, {0.60, 800}, {0.59, 1000}}; !reflect.DeepEqual(book.Bids(), want) {
+		t.Errorf("Bids() = %v, want %v", book.Bids(), want)
+	}
+	if want := []PriceLevel{{0.64, 10}, {0.65, 1800}, {0.66, 900}, {0.67, 600}, {0.68, 400}}; !reflect.DeepEqual(book.Asks(), want) {
+		t.Errorf("Asks() = %v, want %v", book.Asks(), want)
+	}
+	if price, size, ok := book.BestBid(); !ok || price != 0.63 || size != 50 {
+		t.Errorf("BestBid() = (%v, %v, %v)", price, size, ok)
+	}
+	if price, _, ok := book.BestAsk(); !ok || price != 0.64 {
+		t.Errorf("BestAsk() = (%v, %v)", price, ok)
+	}
+	if book.Seq() != 12351 || book.NeedsResync() {
+		t.Errorf("Seq() = %d, NeedsResync() = %v", book.Seq(), book.NeedsResync())
+	}
+
+	out := book.Snapshot()
+	if !out.IsSnapshot || out.Seq != 12351 || len(out.Bids) != 4 {
+		t.Errorf("Snapshot() = %+v", out)
+	}
+}
+
+func TestOrderBook_SequenceErrors(t *testing.T) {
+	book := NewOrderBook(VenueKalshi, "pm_us_election_2028_winner")
+	if err := book.Apply(bookDelta(10, true, []PriceLevel{{0.5, 10}}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	var seqErr *SequenceError
+	err := book.Apply(bookDelta(10, false, []PriceLevel{{0.5, 99}}, nil))
+	if !errors.As(err, &seqErr) || seqErr.Gap() {
+		t.Fatalf("Apply(duplicate) error = %v, want duplicate SequenceError", err)
+	}
+	if book.NeedsResync() || book.Bids()[0].Size != 10 {
+		t.Error("duplicate delta changed the book")
+	}
+
+	err = book.Apply(bookDelta(12, false, []PriceLevel{{0.5, 99}}, nil))
+	if !errors.As(err, &seqErr) || !seqErr.Gap() || seqErr.LastSeq != 10 || seqErr.Seq != 12 {
+		t.Fatalf("Apply(gap) error = %v, want gap SequenceError", err)
+	}
+	if !book.NeedsResync() {
+		t.Error("NeedsResync() = false after gap")
+	}
+	if err := book.Apply(bookDelta(11, false, nil, nil)); !errors.Is(err, ErrBookNotSynced) {
+		t.Errorf("Apply(after gap) error = %v, want ErrBookNotSynced", err)
+	}
+
+	if err := book.Apply(bookDelta(20, true, []PriceLevel{{0.4, 1}}, nil)); err != nil {
+		t.Fatalf("Apply(resync snapshot) error = %v", err)
+	}
+	if book.NeedsResync() || !reflect.DeepEqual(book.Bids(), []PriceLevel{{0.4, 1}}) {
+		t.Errorf("book not reset by snapshot: %v", book.Bids())
+	}
+}
+
+func TestOrderBook_SequenceReset(t *testing.T) {
+	book := NewOrderBook(VenueKalshi, "pm_us_election_2028_winner")
+	if err := book.Apply(bookDelta(500, true, []PriceLevel{{0.5, 10}}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The producer restarted and numbers from 1 again
+	var seqErr *SequenceError
+	err := book.Apply(bookDelta(1, false, []PriceLevel{{0.5, 99}}, nil))
+	if !errors.As(err, &seqErr) || !seqErr.Reset() || seqErr.Gap() {
+		t.Fatalf("Apply(reset) error = %v, want reset SequenceError", err)
+	}
+	if !book.NeedsResync() || book.Bids()[0].Size != 10 {
+		t.Error("backwards seq should invalidate the book without changing it")
+	}
+
+	// Snapshots are accepted whatever their seq
+	if err := book.Apply(bookDelta(2, true, []PriceLevel{{0.4, 1}}, nil)); err != nil {
+		t.Fatalf("Apply(snapshot after reset) error = %v", err)
+	}
+	if book.NeedsResync() || book.Seq() != 2 {
+		t.Errorf("Seq() = %d, NeedsResync() = %v after snapshot", book.Seq(), book.NeedsResync())
+	}
+	if err := book.Apply(bookDelta(3, false, []PriceLevel{{0.4, 2}}, nil)); err != nil {
+		t.Errorf("Apply(delta after snapshot) error = %v", err)
+	}
+	if err := book.Apply(bookDelta(1, true, []PriceLevel{{0.3, 1}}, nil)); err != nil || book.Seq() != 1 {
+		t.Errorf("Apply(older snapshot while synced) = %v, Seq() = %d", err, book.Seq())
+	}
+}
+
+func TestOrderBook_MalformedLevels(t *testing.T) {
+	book := NewOrderBook(VenueKalshi, "pm_us_election_2028_winner")
+	if err := book.Apply(bookDelta(1, true, []PriceLevel{{0.5, 10}}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, levels := range map[string][]PriceLevel{
+		"price above one": {{1.5, 1}},
+		"negative size":   {{0.5, -1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := book.Apply(bookDelta(2, false, []PriceLevel{{0.3, 5}}, levels)); err == nil {
+				t.Error("Apply() expected error, got nil")
+			}
+		})
+	}
+	if book.Seq() != 1 || len(book.Bids()) != 1 {
+		t.Error("malformed delta was partially applied")
+	}
+}
+
+func TestOrderBookEngine_Concurrent(t *testing.T) {
+	engine := NewOrderBookEngine()
+	instruments := []string{"a", "b", "c", "d"}
+
+	var wg sync.WaitGroup
+	for _, id := range instruments {
+		wg.Add(2)
+		go func(id string) {
+			defer wg.Done()
+			snapshot := bookDelta(1, true, []PriceLevel{{0.5, 1}}, []PriceLevel{{0.6, 1}})
+			snapshot.InstrumentID = id
+			if err := engine.Apply(snapshot); err != nil {
+				t.Errorf("Apply() error = %v", err)
+			}
+			for seq := int64(2); seq <= 50; seq++ {
+				delta := bookDelta(seq, false, []PriceLevel{{0.5, float64(seq)}}, nil)
+				delta.InstrumentID = id
+				if err := engine.Apply(delta); err != nil {
+					t.Errorf("Apply() error = %v", err)
+				}
+			}
+		}(id)
+		go func(id string) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if book, ok := engine.Book(VenueKalshi, id); ok {
+					book.Bids()
+					book.BestAsk()
+				}
+				engine.NeedsResync()
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	for _, id := range instruments {
+		book, ok := engine.Book(VenueKalshi, id)
+		if !ok || book.Seq() != 50 {
+			t.Errorf("book %s missing or at wrong seq", id)
+		}
+	}
+
+	gap := bookDelta(60, false, nil, nil)
+	gap.InstrumentID = "b"
+	engine.Apply(gap)
+	if got := engine.NeedsResync(); !reflect.DeepEqual(got, []BookKey{{Venue: VenueKalshi, InstrumentID: "b"}}) {
+		t.Errorf("NeedsResync() = %v", got)
+	}
+}
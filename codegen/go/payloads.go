@@ -0,0 +1,184 @@
+// Package sundayschemas provides typed venue payloads for raw.v0 envelopes
+//
+// Field names follow the payloads connectors publish on raw.venue.{venue}.{stream}
+// (see schemas/examples/raw.*.example.json). Fields not modelled here are ignored.
+package sundayschemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// PolymarketLevel is one price level of a Polymarket book. Polymarket sends
+// prices (probabilities) and sizes (shares) as decimal strings.
+type PolymarketLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// Float64s parses the level's price and size
+func (l PolymarketLevel) Float64s() (price, size float64, err error) {
+	if price, err = strconv.ParseFloat(l.Price, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid price %q: %w", l.Price, err)
+	}
+	if size, err = strconv.ParseFloat(l.Size, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid size %q: %w", l.Size, err)
+	}
+	return price, size, nil
+}
+
+// PolymarketOrderbookPayload is the payload of raw.venue.polymarket.orderbook
+type PolymarketOrderbookPayload struct {
+	MarketID  string            `json:"market_id"`
+	AssetID   string            `json:"asset_id,omitempty"`
+	EventType string            `json:"event_type"`
+	Timestamp int64             `json:"timestamp"`
+	Hash      string            `json:"hash,omitempty"`
+	Bids      []PolymarketLevel `json:"bids"`
+	Asks      []PolymarketLevel `json:"asks"`
+}
+
+// PolymarketTradePayload is the payload of raw.venue.polymarket.trades
+type PolymarketTradePayload struct {
+	MarketID  string `json:"market_id"`
+	AssetID   string `json:"asset_id,omitempty"`
+	TradeID   string `json:"trade_id,omitempty"`
+	EventType string `json:"event_type,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+	// Side is the taker side, "BUY" or "SELL"
+	Side string `json:"side"`
+	// Outcome is the traded outcome token, "Yes" or "No"
+	Outcome    string `json:"outcome,omitempty"`
+	Price      string `json:"price"`
+	Size       string `json:"size"`
+	FeeRateBps string `json:"fee_rate_bps,omitempty"`
+}
+
+// PolymarketStatusPayload is the payload of raw.venue.polymarket.status
+type PolymarketStatusPayload struct {
+	MarketID  string `json:"market_id,omitempty"`
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message,omitempty"`
+}
+
+// KalshiOrderbookPayload is the payload of raw.venue.kalshi.orderbook.
+// Snapshots carry full Yes and No bid ladders as [price_cents, quantity] pairs;
+// deltas carry a single Price/Delta change on Side ("yes" or "no").
+type KalshiOrderbookPayload struct {
+	MarketTicker string     `json:"market_ticker"`
+	EventType    string     `json:"event_type"`
+	Seq          int64      `json:"seq,omitempty"`
+	Timestamp    int64      `json:"timestamp"`
+	Yes          [][2]int64 `json:"yes,omitempty"`
+	No           [][2]int64 `json:"no,omitempty"`
+	Price        int64      `json:"price,omitempty"`
+	Delta        int64      `json:"delta,omitempty"`
+	Side         string     `json:"side,omitempty"`
+}
+
+// KalshiTradePayload is the payload of raw.venue.kalshi.trades
+type KalshiTradePayload struct {
+	MarketTicker string `json:"market_ticker"`
+	TradeID      string `json:"trade_id"`
+	Timestamp    int64  `json:"timestamp"`
+	// Side is the taker side, "yes" or "no"
+	Side string `json:"side"`
+	// PriceCents is the price of the traded side in cents
+	PriceCents   int64  `json:"price_cents"`
+	Count        int64  `json:"count"`
+	TakerOrderID string `json:"taker_order_id,omitempty"`
+}
+
+// KalshiStatusPayload is the payload of raw.venue.kalshi.status
+type KalshiStatusPayload struct {
+	MarketTicker string `json:"market_ticker,omitempty"`
+	Status       string `json:"status"`
+	Timestamp    int64  `json:"timestamp"`
+	Message      string `json:"message,omitempty"`
+}
+
+// UnsupportedPayloadError is returned by TypedPayload when no payload type is
+// registered for a venue and stream
+type UnsupportedPayloadError struct {
+	Venue  VenueID
+	Stream RawEnvelopeV0Stream
+}
+
+func (e UnsupportedPayloadError) Error() string {
+	return fmt.Sprintf("no typed payload for %s %s", e.Venue, e.Stream)
+}
+
+type payloadKey struct {
+	venue  VenueID
+	stream RawEnvelopeV0Stream
+}
+
+var (
+	payloadTypesMu sync.RWMutex
+	// payloadTypes maps each venue and stream to its payload decoder
+	payloadTypes = map[payloadKey]decoderFunc{
+		{VenuePolymarket, Orderbook}: decodePayload[PolymarketOrderbookPayload],
+		{VenuePolymarket, Trades}:    decodePayload[PolymarketTradePayload],
+		{VenuePolymarket, Status}:    decodePayload[PolymarketStatusPayload],
+		{VenueKalshi, Orderbook}:     decodePayload[KalshiOrderbookPayload],
+		{VenueKalshi, Trades}:        decodePayload[KalshiTradePayload],
+		{VenueKalshi, Status}:        decodePayload[KalshiStatusPayload],
+	}
+)
+
+func decodePayload[T any](data []byte) (any, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// RegisterPayloadType registers T as the typed payload for a venue and stream,
+// typically for a venue added to the venue registry at runtime. It replaces
+// any existing registration.
+func RegisterPayloadType[T any](venue VenueID, stream RawEnvelopeV0Stream) error {
+	if err := ValidateVenueStream(venue, stream); err != nil {
+		return err
+	}
+	payloadTypesMu.Lock()
+	defer payloadTypesMu.Unlock()
+	payloadTypes[payloadKey{venue, stream}] = decodePayload[T]
+	return nil
+}
+
+// unregisterPayloadType removes the payload type for a venue and stream. It
+// lets tests undo RegisterPayloadType.
+func unregisterPayloadType(venue VenueID, stream RawEnvelopeV0Stream) {
+	payloadTypesMu.Lock()
+	defer payloadTypesMu.Unlock()
+	delete(payloadTypes, payloadKey{venue, stream})
+}
+
+// TypedPayload decodes the payload into the struct registered for the
+// envelope's venue and stream, e.g. KalshiTradePayload for kalshi/trades.
+// The returned value is a struct value, not a pointer.
+func (r *RawEnvelopeV0) TypedPayload() (any, error) {
+	payloadTypesMu.RLock()
+	decode, ok := payloadTypes[payloadKey{r.VenueID, r.Stream}]
+	payloadTypesMu.RUnlock()
+	if !ok {
+		return nil, UnsupportedPayloadError{Venue: r.VenueID, Stream: r.Stream}
+	}
+	if r.Payload == nil {
+		return nil, fmt.Errorf("%s %s envelope has no payload", r.VenueID, r.Stream)
+	}
+
+	data, err := json.Marshal(r.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s %s payload: %w", r.VenueID, r.Stream, err)
+	}
+	v, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s payload: %w", r.VenueID, r.Stream, err)
+	}
+	return v, nil
+}
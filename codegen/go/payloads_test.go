@@ -0,0 +1,132 @@
+package sundayschemas
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+)
+
+func TestTypedPayload_Examples(t *testing.T) {
+	data := loadExample(t, filepath.Join("..", "..", "schemas", "examples", "raw.kalshi.trade.example.json"))
+	env, err := UnmarshalRawEnvelopeV0(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := env.TypedPayload()
+	if err != nil {
+		t.Fatalf("TypedPayload() error = %v", err)
+	}
+	trade, ok := v.(KalshiTradePayload)
+	if !ok {
+		t.Fatalf("TypedPayload() = %T, want KalshiTradePayload", v)
+	}
+	if trade.MarketTicker != "PRES-28" || trade.Side != "yes" || trade.PriceCents != 63 || trade.Count != 100 || trade.Timestamp != 1758763048456 {
+		t.Errorf("TypedPayload() = %+v", trade)
+	}
+
+	data = loadExample(t, filepath.Join("..", "..", "schemas", "examples", "raw.polymarket.orderbook.example.json"))
+	if env, err = UnmarshalRawEnvelopeV0(data); err != nil {
+		t.Fatal(err)
+	}
+	if v, err = env.TypedPayload(); err != nil {
+		t.Fatalf("TypedPayload() error = %v", err)
+	}
+	book, ok := v.(PolymarketOrderbookPayload)
+	if !ok {
+		t.Fatalf("TypedPayload() = %T, want PolymarketOrderbookPayload", v)
+	}
+	if len(book.Bids) != 2 || len(book.Asks) != 2 {
+		t.Fatalf("TypedPayload() = %+v", book)
+	}
+	price, size, err := book.Bids[0].Float64s()
+	if err != nil || price != 0.63 || size != 1000 {
+		t.Errorf("Bids[0].Float64s() = (%v, %v, %v), want (0.63, 1000, nil)", price, size, err)
+	}
+}
+
+func TestTypedPayload_AllVenueStreams(t *testing.T) {
+	tests := []struct {
+		venue  VenueID
+		stream RawEnvelopeV0Stream
+		want   any
+	}{
+		{VenuePolymarket, Orderbook, PolymarketOrderbookPayload{}},
+		{VenuePolymarket, Trades, PolymarketTradePayload{}},
+		{VenuePolymarket, Status, PolymarketStatusPayload{}},
+		{VenueKalshi, Orderbook, KalshiOrderbookPayload{}},
+		{VenueKalshi, Trades, KalshiTradePayload{}},
+		{VenueKalshi, Status, KalshiStatusPayload{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.venue)+"/"+string(tt.stream), func(t *testing.T) {
+			env := RawEnvelopeV0{VenueID: tt.venue, Stream: tt.stream, Payload: map[string]interface{}{"timestamp": 1.0}}
+			v, err := env.TypedPayload()
+			if err != nil {
+				t.Fatalf("TypedPayload() error = %v", err)
+			}
+			if reflect.TypeOf(v) != reflect.TypeOf(tt.want) {
+				t.Errorf("TypedPayload() = %T, want %T", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedPayload_KalshiOrderbookLevels(t *testing.T) {
+	env := RawEnvelopeV0{VenueID: VenueKalshi, Stream: Orderbook, Payload: map[string]interface{}{
+		"market_ticker": "PRES-28",
+		"event_type":    "orderbook_snapshot",
+		"yes":           []interface{}{[]interface{}{63.0, 100.0}},
+		"no":            []interface{}{[]interface{}{35.0, 40.0}},
+	}}
+	v, err := env.TypedPayload()
+	if err != nil {
+		t.Fatalf("TypedPayload() error = %v", err)
+	}
+	book := v.(KalshiOrderbookPayload)
+	if len(book.Yes) != 1 || book.Yes[0] != [2]int64{63, 100} || book.No[0] != [2]int64{35, 40} {
+		t.Errorf("TypedPayload() = %+v", book)
+	}
+}
+
+func TestTypedPayload_Errors(t *testing.T) {
+	var unsupported UnsupportedPayloadError
+	env := RawEnvelopeV0{VenueID: "manifold", Stream: Trades, Payload: map[string]interface{}{}}
+	if _, err := env.TypedPayload(); !errors.As(err, &unsupported) {
+		t.Errorf("TypedPayload() error = %v, want UnsupportedPayloadError", err)
+	}
+
+	env = RawEnvelopeV0{VenueID: VenueKalshi, Stream: Trades, Payload: map[string]interface{}{"price_cents": "63"}}
+	if _, err := env.TypedPayload(); err == nil {
+		t.Error("TypedPayload() expected error for mistyped field, got nil")
+	}
+}
+
+func TestRegisterPayloadType(t *testing.T) {
+	type payload struct {
+		Probability float64 `json:"probability"`
+	}
+	if err := venues.Register(venues.Descriptor{ID: "payloadvenue", PriceUnit: venues.PriceUnitProbability, ContractSize: 1, Streams: []string{"trades"}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { venues.Unregister("payloadvenue") })
+	if err := RegisterPayloadType[payload]("payloadvenue", Trades); err != nil {
+		t.Fatalf("RegisterPayloadType() error = %v", err)
+	}
+	t.Cleanup(func() { unregisterPayloadType("payloadvenue", Trades) })
+	if err := RegisterPayloadType[payload]("payloadvenue", Orderbook); err == nil {
+		t.Error("RegisterPayloadType() expected error for unsupported stream, got nil")
+	}
+
+	env := RawEnvelopeV0{VenueID: "payloadvenue", Stream: Trades, Payload: map[string]interface{}{"probability": 0.4}}
+	v, err := env.TypedPayload()
+	if err != nil {
+		t.Fatalf("TypedPayload() error = %v", err)
+	}
+	if p, ok := v.(payload); !ok || p.Probability != 0.4 {
+		t.Errorf("TypedPayload() = %#v", v)
+	}
+}
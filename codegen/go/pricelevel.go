@@ -0,0 +1,103 @@
+// Package sundayschemas provides typed order book price levels
+package sundayschemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// PriceLevel is one [price, size] row of an order book ladder. Price is an
+// implied probability in [0, 1] and Size is the quantity at that price; a
+// size of 0 in a delta removes the level. On the wire it is the two-element
+// array used by md.orderbook.delta.v1.
+type PriceLevel struct {
+	Price float64
+	Size  float64
+}
+
+// MarshalJSON encodes the level as [price, size]
+func (l PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{l.Price, l.Size})
+}
+
+// UnmarshalJSON decodes a [price, size] array, rejecting rows that are not
+// exactly two numbers
+func (l *PriceLevel) UnmarshalJSON(data []byte) error {
+	var row []json.RawMessage
+	if err := json.Unmarshal(data, &row); err != nil {
+		return fmt.Errorf("price level must be a [price, size] array: %w", err)
+	}
+	if len(row) != 2 {
+		return fmt.Errorf("price level has %d elements, want [price, size]", len(row))
+	}
+	var values [2]float64
+	for i, raw := range row {
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			return fmt.Errorf("price level element %d is null", i)
+		}
+		if err := json.Unmarshal(raw, &values[i]); err != nil {
+			return fmt.Errorf("price level element %d: %w", i, err)
+		}
+	}
+	l.Price, l.Size = values[0], values[1]
+	return nil
+}
+
+// Validate checks that the price is a probability and the size is non-negative
+func (l PriceLevel) Validate() error {
+	if !(l.Price >= 0 && l.Price <= 1) {
+		return fmt.Errorf("price %v outside [0, 1]", l.Price)
+	}
+	if !(l.Size >= 0) || math.IsInf(l.Size, 0) {
+		return fmt.Errorf("size %v must be a finite number >= 0", l.Size)
+	}
+	return nil
+}
+
+// SortBids sorts a bid ladder by descending price
+func SortBids(levels []PriceLevel) {
+	sort.SliceStable(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+}
+
+// SortAsks sorts an ask ladder by ascending price
+func SortAsks(levels []PriceLevel) {
+	sort.SliceStable(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+}
+
+// ValidateLevels checks every level without regard to order
+func ValidateLevels(levels []PriceLevel) error {
+	for i, level := range levels {
+		if err := level.Validate(); err != nil {
+			return fmt.Errorf("level %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ValidateBids checks a bid ladder: valid levels in strictly descending price order
+func ValidateBids(levels []PriceLevel) error {
+	return validateLadder(levels, func(prev, cur float64) bool { return cur < prev }, "descending")
+}
+
+// ValidateAsks checks an ask ladder: valid levels in strictly ascending price order
+func ValidateAsks(levels []PriceLevel) error {
+	return validateLadder(levels, func(prev, cur float64) bool { return cur > prev }, "ascending")
+}
+
+func validateLadder(levels []PriceLevel, ordered func(prev, cur float64) bool, order string) error {
+	if err := ValidateLevels(levels); err != nil {
+		return err
+	}
+	for i := 1; i < len(levels); i++ {
+		if levels[i].Price == levels[i-1].Price {
+			return fmt.Errorf("level %d: duplicate price %v", i, levels[i].Price)
+		}
+		if !ordered(levels[i-1].Price, levels[i].Price) {
+			return fmt.Errorf("level %d: price %v breaks %s order", i, levels[i].Price, order)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package sundayschemas
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPriceLevel_JSONRoundTrip(t *testing.T) {
+	data := loadExample(t, filepath.Join("..", "..", "schemas", "examples", "md.orderbook.delta.example.json"))
+	delta, err := UnmarshalNormalizedOrderBookDeltaV1(data)
+	if err != nil {
+		t.Fatalf("UnmarshalNormalizedOrderBookDeltaV1() error = %v", err)
+	}
+	if delta.Bids[0] != (PriceLevel{Price: 0.63, Size: 1000}) || len(delta.Asks) != 3 {
+		t.Errorf("decoded ladders = %v / %v", delta.Bids, delta.Asks)
+	}
+
+	out, err := delta.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wire struct {
+		Bids [][]float64 `json:"bids"`
+	}
+	if err := json.Unmarshal(out, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(wire.Bids[0], []float64{0.63, 1000}) {
+		t.Errorf("bids encoded as %v, want [price, size] arrays", wire.Bids)
+	}
+	if err := ValidateJSON(SchemaMD_ORDERBOOK_DELTA_V1, out); err != nil {
+		t.Errorf("re-encoded delta fails schema validation: %v", err)
+	}
+}
+
+func TestPriceLevel_UnmarshalRejectsMalformedRows(t *testing.T) {
+	for _, row := range []string{`[0.5]`, `[0.5, 1, 2]`, `[]`, `{"price": 0.5, "size": 1}`, `["0.5", 1]`, `[0.5, null]`, `0.5`} {
+		t.Run(row, func(t *testing.T) {
+			var level PriceLevel
+			if err := json.Unmarshal([]byte(row), &level); err == nil {
+				t.Errorf("Unmarshal(%s) expected error, got %v", row, level)
+			}
+		})
+	}
+
+	doc := `{"schema":"md.orderbook.delta.v1","instrument_id":"i","venue_id":"kalshi","seq":1,"ts_ms":1,"bids":[[0.5,10,3]],"asks":[],"is_snapshot":false}`
+	if _, err := UnmarshalNormalizedOrderBookDeltaV1([]byte(doc)); err == nil {
+		t.Error("UnmarshalNormalizedOrderBookDeltaV1() accepted a three-element row")
+	}
+	if _, err := UnmarshalNormalizedOrderBookDeltaV1([]byte(`{"bids":[[0.5,10]],"asks":[[0.6]]}`)); err == nil {
+		t.Error("UnmarshalNormalizedOrderBookDeltaV1() accepted a one-element row")
+	}
+}
+
+func TestPriceLevel_Ladders(t *testing.T) {
+	bids := []PriceLevel{{0.61, 1}, {0.63, 2}, {0.62, 3}}
+	SortBids(bids)
+	if err := ValidateBids(bids); err != nil || bids[0].Price != 0.63 {
+		t.Errorf("SortBids() = %v, ValidateBids() = %v", bids, err)
+	}
+	if err := ValidateAsks(bids); err == nil {
+		t.Error("ValidateAsks() accepted a descending ladder")
+	}
+
+	asks := []PriceLevel{{0.66, 1}, {0.64, 2}, {0.65, 3}}
+	SortAsks(asks)
+	if err := ValidateAsks(asks); err != nil || asks[0].Price != 0.64 {
+		t.Errorf("SortAsks() = %v, ValidateAsks() = %v", asks, err)
+	}
+
+	tests := []struct {
+		name   string
+		levels []PriceLevel
+	}{
+		{"duplicate price", []PriceLevel{{0.6, 1}, {0.6, 2}}},
+		{"price above one", []PriceLevel{{1.2, 1}}},
+		{"negative price", []PriceLevel{{-0.1, 1}}},
+		{"negative size", []PriceLevel{{0.5, -1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateBids(tt.levels); err == nil {
+				t.Error("ValidateBids() expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Package sundayschemas provides a JSON Schema registry for Sunday platform schemas
+//
+// The schema documents under jsonschema/ are copied from schemas/json by
+// 'npm run generate-go' and embedded into the module, so Go services get the
+// same validation answer as scripts/validate-examples.js without Node. The one
+// difference is venues: an enum listing exactly the built-in venue IDs is
+// checked against the runtime venue registry, so a venue added with
+// venues.Register is accepted.
+package sundayschemas
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+//go:embed jsonschema/*.json
+var embeddedSchemas embed.FS
+
+const embeddedSchemasDir = "jsonschema"
+
+// UnknownSchemaError is returned when a schema identifier is not registered
+type UnknownSchemaError struct {
+	Schema string
+}
+
+func (e UnknownSchemaError) Error() string {
+	return fmt.Sprintf("unknown schema: %q", e.Schema)
+}
+
+// SchemaViolation describes a single JSON Schema keyword failure
+type SchemaViolation struct {
+	// InstanceLocation is a JSON Pointer into the validated document
+	InstanceLocation string
+	// SchemaLocation is the absolute location of the failing keyword
+	SchemaLocation string
+	Message        string
+}
+
+func (v SchemaViolation) String() string {
+	loc := v.InstanceLocation
+	if loc == "" {
+		loc = "/"
+	}
+	return fmt.Sprintf("%s: %s", loc, v.Message)
+}
+
+// SchemaValidationError reports every violation found while validating a document
+type SchemaValidationError struct {
+	Schema     EventSchema
+	Violations []SchemaViolation
+}
+
+func (e *SchemaValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return fmt.Sprintf("%s validation failed: %s", e.Schema, strings.Join(msgs, "; "))
+}
+
+// SchemaRegistry holds compiled JSON Schemas keyed by schema identifier
+type SchemaRegistry struct {
+	schemas map[EventSchema]*jsonschema.Schema
+}
+
+var (
+	defaultRegistry     *SchemaRegistry
+	defaultRegistryErr  error
+	defaultRegistryOnce sync.Once
+)
+
+// DefaultSchemaRegistry returns the registry compiled from the embedded schemas.
+// The embedded schemas are compiled once; a compile failure is a build defect and panics.
+func DefaultSchemaRegistry() *SchemaRegistry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistry, defaultRegistryErr = NewSchemaRegistry()
+	})
+	if defaultRegistryErr != nil {
+		panic(defaultRegistryErr)
+	}
+	return defaultRegistry
+}
+
+// NewSchemaRegistry compiles every embedded schema document into a new registry
+func NewSchemaRegistry() (*SchemaRegistry, error) {
+	sub, err := fs.Sub(embeddedSchemas, embeddedSchemasDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchemaRegistryFS(sub)
+}
+
+// NewSchemaRegistryFS compiles every *.schema.json document found at the root of fsys.
+// Schemas are keyed by their "schema" const when present, otherwise by file name
+// without the ".schema.json" suffix (e.g. "raw.events.v0", "discovery.event-payload.v0").
+func NewSchemaRegistryFS(fsys fs.FS) (*SchemaRegistry, error) {
+	files, err := fs.Glob(fsys, "*.schema.json")
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.RegisterVocabulary(venueVocabulary)
+	compiler.AssertVocabs()
+	builtinVenues := builtinVenueIDs()
+	ids := make(map[EventSchema]string, len(files))
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: schema document must be an object", file)
+		}
+
+		upgradeTupleItems(obj)
+		useVenueRegistry(obj, builtinVenues)
+
+		id, _ := obj["$id"].(string)
+		if id == "" {
+			id = "https://schemas.sunday.dev/" + path.Base(file)
+		}
+		if err := compiler.AddResource(id, obj); err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", file, err)
+		}
+		ids[schemaKey(file, obj)] = id
+	}
+
+	r := &SchemaRegistry{schemas: make(map[EventSchema]*jsonschema.Schema, len(ids))}
+	for key, id := range ids {
+		sch, err := compiler.Compile(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile %s: %w", key, err)
+		}
+		r.schemas[key] = sch
+	}
+
+	return r, nil
+}
+
+// Validate validates a raw JSON document against the named schema
+func (r *SchemaRegistry) Validate(schema EventSchema, data []byte) error {
+	sch, ok := r.schemas[schema]
+	if !ok {
+		return UnknownSchemaError{Schema: string(schema)}
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s document: %w", schema, err)
+	}
+
+	if err := sch.Validate(doc); err != nil {
+		verr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return err
+		}
+		return &SchemaValidationError{Schema: schema, Violations: collectViolations(verr)}
+	}
+
+	return nil
+}
+
+// Has reports whether the registry contains the named schema
+func (r *SchemaRegistry) Has(schema EventSchema) bool {
+	_, ok := r.schemas[schema]
+	return ok
+}
+
+// Schemas returns all registered schema identifiers in sorted order
+func (r *SchemaRegistry) Schemas() []EventSchema {
+	out := make([]EventSchema, 0, len(r.schemas))
+	for key := range r.schemas {
+		out = append(out, key)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// ValidateJSON validates a raw JSON document against the named embedded schema
+func ValidateJSON(schema EventSchema, data []byte) error {
+	return DefaultSchemaRegistry().Validate(schema, data)
+}
+
+func schemaKey(file string, obj map[string]any) EventSchema {
+	if props, ok := obj["properties"].(map[string]any); ok {
+		if prop, ok := props["schema"].(map[string]any); ok {
+			if c, ok := prop["const"].(string); ok {
+				return EventSchema(c)
+			}
+		}
+	}
+	return EventSchema(strings.TrimSuffix(path.Base(file), ".schema.json"))
+}
+
+// upgradeTupleItems rewrites the draft-07 tuple form "items": [...] into the
+// draft 2020-12 "prefixItems" keyword. md.orderbook.delta.v1 still uses the
+// older form, which Ajv accepts in non-strict mode as a tuple.
+func upgradeTupleItems(node any) {
+	switch n := node.(type) {
+	case map[string]any:
+		if items, ok := n["items"].([]any); ok {
+			if _, exists := n["prefixItems"]; !exists {
+				n["prefixItems"] = items
+				delete(n, "items")
+			}
+		}
+		for k, v := range n {
+			switch k {
+			case "const", "enum", "default", "examples":
+				continue
+			}
+			upgradeTupleItems(v)
+		}
+	case []any:
+		for _, v := range n {
+			upgradeTupleItems(v)
+		}
+	}
+}
+
+// venueKeyword replaces venue enums; its value is ignored
+const venueKeyword = "x-sunday-venue"
+
+var venueVocabulary = &jsonschema.Vocabulary{
+	URL: "https://schemas.sunday.dev/vocab/venue",
+	Compile: func(_ *jsonschema.CompilerContext, obj map[string]any) (jsonschema.SchemaExt, error) {
+		if _, ok := obj[venueKeyword]; !ok {
+			return nil, nil
+		}
+		return venueCheck{}, nil
+	},
+}
+
+// venueCheck accepts only strings that are IDs in the default venue
+// registry, as the enum it replaces did
+type venueCheck struct{}
+
+func (venueCheck) Validate(ctx *jsonschema.ValidatorContext, v any) {
+	if id, ok := v.(string); !ok || !venues.IsValid(id) {
+		ctx.AddError(&unknownVenue{ID: v})
+	}
+}
+
+// unknownVenue is the jsonschema.ErrorKind for a venue the registry does not know
+type unknownVenue struct {
+	ID any
+}
+
+func (*unknownVenue) KeywordPath() []string {
+	return []string{venueKeyword}
+}
+
+func (k *unknownVenue) LocalizedString(p *message.Printer) string {
+	if id, ok := k.ID.(string); ok {
+		return p.Sprintf("%q is not a registered venue", id)
+	}
+	return p.Sprintf("%v is not a registered venue", k.ID)
+}
+
+func builtinVenueIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, d := range venues.Builtins() {
+		ids[d.ID] = true
+	}
+	return ids
+}
+
+// useVenueRegistry rewrites every enum that lists exactly the built-in venue
+// IDs into the venue keyword
+func useVenueRegistry(node any, builtin map[string]bool) {
+	switch n := node.(type) {
+	case map[string]any:
+		if enum, ok := n["enum"].([]any); ok && isVenueEnum(enum, builtin) {
+			delete(n, "enum")
+			n[venueKeyword] = true
+		}
+		for k, v := range n {
+			switch k {
+			case "const", "enum", "default", "examples":
+				continue
+			}
+			useVenueRegistry(v, builtin)
+		}
+	case []any:
+		for _, v := range n {
+			useVenueRegistry(v, builtin)
+		}
+	}
+}
+
+func isVenueEnum(enum []any, builtin map[string]bool) bool {
+	if len(enum) != len(builtin) {
+		return false
+	}
+	for _, v := range enum {
+		if id, ok := v.(string); !ok || !builtin[id] {
+			return false
+		}
+	}
+	return true
+}
+
+var violationPrinter = message.NewPrinter(language.English)
+
+// collectViolations flattens the validation error tree into its leaf failures
+func collectViolations(err *jsonschema.ValidationError) []SchemaViolation {
+	var out []SchemaViolation
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			out = append(out, SchemaViolation{
+				InstanceLocation: jsonPointer(e.InstanceLocation),
+				SchemaLocation:   e.SchemaURL + jsonPointer(e.ErrorKind.KeywordPath()),
+				Message:          e.ErrorKind.LocalizedString(violationPrinter),
+			})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(err)
+	return out
+}
+
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		tok = strings.ReplaceAll(tok, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(tok, "/", "~1"))
+	}
+	return sb.String()
+}
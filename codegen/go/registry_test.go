@@ -0,0 +1,239 @@
+package sundayschemas
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+)
+
+func TestSchemaRegistry_ContainsAllSchemas(t *testing.T) {
+	registry := DefaultSchemaRegistry()
+
+	for _, schema := range AllSchemas() {
+		if !registry.Has(schema) {
+			t.Errorf("registry missing schema %s", schema)
+		}
+	}
+
+	for _, schema := range []EventSchema{"discovery.event-payload.v0", "discovery.series-payload.v0"} {
+		if !registry.Has(schema) {
+			t.Errorf("registry missing schema %s", schema)
+		}
+	}
+}
+
+func TestSchemaRegistry_ValidExamples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "schemas", "examples", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list examples: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no examples found")
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data := loadExample(t, file)
+			schema := exampleSchema(t, data)
+			if err := ValidateJSON(schema, data); err != nil {
+				t.Errorf("ValidateJSON(%s) error = %v, want nil", schema, err)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistry_DiscoveryExamples(t *testing.T) {
+	tests := []struct {
+		filename string
+		schema   EventSchema
+		wantErr  bool
+	}{
+		{"event-payload-polymarket-valid.json", "discovery.event-payload.v0", false},
+		{"event-payload-kalshi-valid.json", "discovery.event-payload.v0", false},
+		{"minimal-event-payload.json", "discovery.event-payload.v0", false},
+		{"event-payload-invalid-missing-required.json", "discovery.event-payload.v0", true},
+		{"event-payload-invalid-wrong-enum.json", "discovery.event-payload.v0", true},
+		{"series-payload-polymarket-valid.json", "discovery.series-payload.v0", false},
+		{"series-payload-kalshi-valid.json", "discovery.series-payload.v0", false},
+		{"minimal-series-payload.json", "discovery.series-payload.v0", false},
+		{"series-payload-invalid-kind-mismatch.json", "discovery.series-payload.v0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			data := loadExample(t, filepath.Join("..", "..", "schemas", "examples", "discovery", tt.filename))
+			err := ValidateJSON(tt.schema, data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistry_Violations(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   EventSchema
+		doc      string
+		location string
+	}{
+		{
+			name:     "prob above maximum",
+			schema:   SchemaMD_TRADE_V1,
+			doc:      `{"schema":"md.trade.v1","instrument_id":"i","venue_id":"kalshi","ts_ms":1,"side":"buy","prob":1.5,"size":1}`,
+			location: "/prob",
+		},
+		{
+			name:     "schema const mismatch",
+			schema:   SchemaMD_TRADE_V1,
+			doc:      `{"schema":"md.trade.v2","instrument_id":"i","venue_id":"kalshi","ts_ms":1,"side":"buy","prob":0.5,"size":1}`,
+			location: "/schema",
+		},
+		{
+			name:     "additional property",
+			schema:   SchemaINSIGHTS_MOVERS_V1,
+			doc:      `{"schema":"insights.movers.v1","instrument_id":"i","window":"1h","prob_now":0.5,"prob_prev":0.4,"delta_bps":1000,"imbalance_index":5,"ts_ms":1,"extra":true}`,
+			location: "",
+		},
+		{
+			name:     "orderbook level out of range",
+			schema:   SchemaMD_ORDERBOOK_DELTA_V1,
+			doc:      `{"schema":"md.orderbook.delta.v1","instrument_id":"i","venue_id":"kalshi","seq":1,"ts_ms":1,"bids":[[1.2,10]],"asks":[],"is_snapshot":false}`,
+			location: "/bids/0/0",
+		},
+		{
+			name:     "orderbook level too long",
+			schema:   SchemaMD_ORDERBOOK_DELTA_V1,
+			doc:      `{"schema":"md.orderbook.delta.v1","instrument_id":"i","venue_id":"kalshi","seq":1,"ts_ms":1,"bids":[[0.5,10,3]],"asks":[],"is_snapshot":false}`,
+			location: "/bids/0",
+		},
+		{
+			name:     "shared ref enforced through series payload",
+			schema:   "discovery.series-payload.v0",
+			doc:      `{"event":{"kind":"series","venue_id":"kalshi","event_id":"S","title":"T","active":true,"closed":false,"discovered_at":"2025-11-04T09:00:00Z","last_seen":"2025-11-04T12:00:00Z","series_data":{"financial":{"volume_24h_usd":-1}}},"event_id":"e","event_type":"updated","timestamp":"2025-11-04T12:00:00Z","venue_id":"kalshi"}`,
+			location: "/event/series_data/financial/volume_24h_usd",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSON(tt.schema, []byte(tt.doc))
+			var verr *SchemaValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("ValidateJSON() error = %v, want *SchemaValidationError", err)
+			}
+			found := false
+			for _, v := range verr.Violations {
+				if v.InstanceLocation == tt.location {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected violation at %q, got %v", tt.location, verr.Violations)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistry_RuntimeVenues(t *testing.T) {
+	trade := []byte(`{"schema":"md.trade.v1","instrument_id":"i","venue_id":"manifold","ts_ms":1,"side":"buy","prob":0.5,"size":1}`)
+	var verr *SchemaValidationError
+	if err := ValidateJSON(SchemaMD_TRADE_V1, trade); !errors.As(err, &verr) || verr.Violations[0].InstanceLocation != "/venue_id" {
+		t.Fatalf("ValidateJSON() error = %v, want a /venue_id violation", err)
+	}
+
+	manifold := venues.Descriptor{ID: "manifold", DisplayName: "Manifold", PriceUnit: venues.PriceUnitProbability, ContractSize: 1, Streams: []string{venues.StreamTrades}}
+	if err := venues.Register(manifold); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { venues.Unregister("manifold") })
+	if err := ValidateJSON(SchemaMD_TRADE_V1, trade); err != nil {
+		t.Errorf("ValidateJSON() error = %v after venues.Register", err)
+	}
+
+	// Every venue enum is checked, whatever the field is called
+	for short, wantErr := range map[string]bool{`"kalshi"`: false, `"nowhere"`: true, `7`: true} {
+		arb := []byte(`{"schema":"insights.arb.lite.v1","instrument_id":"i","long_venue":"manifold","short_venue":` + short +
+			`,"edge_bps":43.5,"depth_tier":"M","persistence_ms":1,"last_seen_ms":1,"fees_included":false}`)
+		err := ValidateJSON(SchemaINSIGHTS_ARB_LITE_V1, arb)
+		if !wantErr && err != nil || wantErr && (!errors.As(err, &verr) || len(verr.Violations) != 1 || verr.Violations[0].InstanceLocation != "/short_venue") {
+			t.Errorf("ValidateJSON(short_venue %s) error = %v, wantErr %v", short, err, wantErr)
+		}
+	}
+}
+
+func TestSchemaRegistry_UnknownSchema(t *testing.T) {
+	err := ValidateJSON("md.trade.v9", []byte(`{}`))
+	var unknown UnknownSchemaError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("ValidateJSON() error = %v, want UnknownSchemaError", err)
+	}
+	if unknown.Schema != "md.trade.v9" {
+		t.Errorf("UnknownSchemaError.Schema = %q, want md.trade.v9", unknown.Schema)
+	}
+}
+
+func TestSchemaRegistry_InvalidJSON(t *testing.T) {
+	if err := ValidateJSON(SchemaMD_TRADE_V1, []byte(`{"schema":`)); err == nil {
+		t.Error("ValidateJSON() expected error for malformed JSON, got nil")
+	}
+}
+
+func TestEmbeddedSchemasInSync(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("..", "..", "schemas", "json", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list schemas: %v", err)
+	}
+
+	for _, source := range sources {
+		want, err := os.ReadFile(source)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", source, err)
+		}
+		got, err := embeddedSchemas.ReadFile(embeddedSchemasDir + "/" + filepath.Base(source))
+		if err != nil {
+			t.Errorf("%s is not embedded; run 'npm run generate-go'", filepath.Base(source))
+			continue
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s is out of date; run 'npm run generate-go'", filepath.Base(source))
+		}
+	}
+}
+
+func loadExample(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load example %s: %v", path, err)
+	}
+	return data
+}
+
+// exampleSchema reads the schema identifier from either the top-level
+// "schema" field or the nested "envelope.schema" field
+func exampleSchema(t *testing.T, data []byte) EventSchema {
+	t.Helper()
+	var probe struct {
+		Schema   string `json:"schema"`
+		Envelope struct {
+			Schema string `json:"schema"`
+		} `json:"envelope"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		t.Fatalf("Failed to parse example: %v", err)
+	}
+	schema := probe.Schema
+	if schema == "" {
+		schema = probe.Envelope.Schema
+	}
+	if strings.TrimSpace(schema) == "" {
+		t.Fatal("example has no schema identifier")
+	}
+	return EventSchema(schema)
+}
@@ -403,18 +403,24 @@ type Creators struct {
 }
 
 type Financial struct {
-	// Currency unit for monetary values                           
+	// Currency of the *_native amounts; *_usd amounts are always USD. Defaults to USD
 	Currency                                             *Currency `json:"currency,omitempty"`
+	// Total liquidity in the native currency, decimal precision up to the currency's minor units
+	LiquidityTotalNative                                 *float64  `json:"liquidity_total_native,omitempty"`
 	// Total USD liquidity, decimal precision to 2 places          
 	LiquidityTotalUsd                                    *float64  `json:"liquidity_total_usd,omitempty"`
 	// Ranking/scoring metric (unitless)                           
 	Score                                                *float64  `json:"score,omitempty"`
 	// 24-hour contract volume count                               
 	Volume24HContracts                                   *int64    `json:"volume_24h_contracts,omitempty"`
+	// 24-hour volume in the native currency, decimal precision up to the currency's minor units
+	Volume24HNative                                      *float64  `json:"volume_24h_native,omitempty"`
 	// 24-hour USD volume, decimal precision to 2 places           
 	Volume24HUsd                                         *float64  `json:"volume_24h_usd,omitempty"`
 	// Total contract volume count                                 
 	VolumeTotalContracts                                 *int64    `json:"volume_total_contracts,omitempty"`
+	// Total volume in the native currency, decimal precision up to the currency's minor units
+	VolumeTotalNative                                    *float64  `json:"volume_total_native,omitempty"`
 	// Total USD volume, decimal precision to 2 places             
 	VolumeTotalUsd                                       *float64  `json:"volume_total_usd,omitempty"`
 }
@@ -546,9 +552,9 @@ type WhaleFlowsLiteV1 struct {
 // [0.0, 1.0].
 type NormalizedOrderBookDeltaV1 struct {
 	// Array of [price, size] pairs where price is implied probability [0.0, 1.0]                                 
-	Asks                                                                         [][]float64                      `json:"asks"`
+	Asks                                                                         []PriceLevel                     `json:"asks"`
 	// Array of [price, size] pairs where price is implied probability [0.0, 1.0]                                 
-	Bids                                                                         [][]float64                      `json:"bids"`
+	Bids                                                                         []PriceLevel                     `json:"bids"`
 	InstrumentID                                                                 string                           `json:"instrument_id"`
 	IsSnapshot                                                                   bool                             `json:"is_snapshot"`
 	Schema                                                                       NormalizedOrderBookDeltaV1Schema `json:"schema"`
@@ -696,11 +702,14 @@ const (
 	Series SeriesMetadataV0Kind = "series"
 )
 
-// Currency unit for monetary values
+// Currency of the *_native amounts; *_usd amounts are always USD. Defaults to USD
 type Currency string
 
 const (
-	Usd Currency = "USD"
+	Eur  Currency = "EUR"
+	Gbp  Currency = "GBP"
+	Usd  Currency = "USD"
+	Usdc Currency = "USDC"
 )
 
 type VenueHealthV1Schema string
@@ -0,0 +1,269 @@
+// Package sundayschemas provides the Kafka topic registry for Sunday platform schemas
+//
+// topics.json is copied from schemas/topics.json by 'npm run generate-go'.
+package sundayschemas
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+)
+
+//go:embed topics.json
+var embeddedTopics []byte
+
+// RawTopicPrefix is the prefix of the templated raw.venue.{venue}.{stream} topic family
+const RawTopicPrefix = "raw.venue."
+
+// TopicInfo describes the topics a schema is published to
+type TopicInfo struct {
+	Schema      EventSchema
+	Topics      []string
+	Description string
+}
+
+// TopicRegistry resolves schemas to topics and topics to schemas
+type TopicRegistry struct {
+	bySchema map[EventSchema]TopicInfo
+	byTopic  map[string]EventSchema
+}
+
+var (
+	defaultTopics     *TopicRegistry
+	defaultTopicsErr  error
+	defaultTopicsOnce sync.Once
+)
+
+// DefaultTopicRegistry returns the registry parsed from the embedded topics.json.
+// A parse failure is a build defect and panics.
+func DefaultTopicRegistry() *TopicRegistry {
+	defaultTopicsOnce.Do(func() {
+		defaultTopics, defaultTopicsErr = ParseTopicRegistry(embeddedTopics)
+	})
+	if defaultTopicsErr != nil {
+		panic(defaultTopicsErr)
+	}
+	return defaultTopics
+}
+
+// ParseTopicRegistry parses a topics.json document. Each entry may use either
+// a single "topic" or a list of "topics".
+func ParseTopicRegistry(data []byte) (*TopicRegistry, error) {
+	var raw map[string]struct {
+		Topic       string   `json:"topic"`
+		Topics      []string `json:"topics"`
+		Description string   `json:"description"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse topics: %w", err)
+	}
+
+	r := &TopicRegistry{
+		bySchema: make(map[EventSchema]TopicInfo, len(raw)),
+		byTopic:  make(map[string]EventSchema),
+	}
+
+	for id, entry := range raw {
+		schema := EventSchema(id)
+		topics := entry.Topics
+		if entry.Topic != "" {
+			topics = append([]string{entry.Topic}, topics...)
+		}
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("schema %s has no topics", id)
+		}
+
+		for _, topic := range topics {
+			if owner, ok := r.byTopic[topic]; ok && owner != schema {
+				return nil, fmt.Errorf("topic %s is mapped to both %s and %s", topic, owner, schema)
+			}
+			r.byTopic[topic] = schema
+		}
+
+		r.bySchema[schema] = TopicInfo{
+			Schema:      schema,
+			Topics:      topics,
+			Description: entry.Description,
+		}
+	}
+
+	return r, nil
+}
+
+// Schema returns the topic entry for a schema
+func (r *TopicRegistry) Schema(schema EventSchema) (TopicInfo, bool) {
+	info, ok := r.bySchema[schema]
+	return info, ok
+}
+
+// TopicsForSchema returns the topics listed for a schema in topics.json
+func (r *TopicRegistry) TopicsForSchema(schema EventSchema) []string {
+	info, ok := r.bySchema[schema]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), info.Topics...)
+}
+
+// SchemaForTopic returns the schema published on a topic. Topics in the
+// raw.venue.{venue}.{stream} family resolve to raw.v0 for any valid venue
+// and stream, even when they are not listed in topics.json.
+func (r *TopicRegistry) SchemaForTopic(topic string) (EventSchema, bool) {
+	if schema, ok := r.byTopic[topic]; ok {
+		return schema, true
+	}
+	if _, _, err := ParseRawTopic(topic); err == nil {
+		return SchemaRAW_V0, true
+	}
+	return "", false
+}
+
+// Topics returns every concrete topic known to the registry in sorted order,
+// including the full raw.venue.{venue}.{stream} expansion.
+func (r *TopicRegistry) Topics() []string {
+	seen := make(map[string]bool, len(r.byTopic))
+	for topic := range r.byTopic {
+		seen[topic] = true
+	}
+	for _, venue := range venues.Default().Descriptors() {
+		for _, stream := range AllRawStreams() {
+			if venue.SupportsStream(string(stream)) {
+				seen[RawTopicPrefix+venue.ID+"."+string(stream)] = true
+			}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for topic := range seen {
+		out = append(out, topic)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// MatchTopics returns the known topics matching a subscription pattern (see TopicMatches)
+func (r *TopicRegistry) MatchTopics(pattern string) []string {
+	var out []string
+	for _, topic := range r.Topics() {
+		if TopicMatches(pattern, topic) {
+			out = append(out, topic)
+		}
+	}
+	return out
+}
+
+// DiscoveryTopic returns the topic for a discovery schema and event type,
+// e.g. raw.events.v0 + updated -> sunday.events.updated
+func (r *TopicRegistry) DiscoveryTopic(schema EventSchema, eventType EventType) (string, error) {
+	info, ok := r.bySchema[schema]
+	if !ok {
+		return "", UnknownSchemaError{Schema: string(schema)}
+	}
+	for _, topic := range info.Topics {
+		if strings.HasSuffix(topic, "."+string(eventType)) {
+			return topic, nil
+		}
+	}
+	return "", fmt.Errorf("schema %s has no topic for event type %q", schema, eventType)
+}
+
+// AllRawStreams returns every valid raw.v0 stream
+func AllRawStreams() []RawEnvelopeV0Stream {
+	return []RawEnvelopeV0Stream{Orderbook, Trades, Status}
+}
+
+// ValidateRawStream checks if a stream is valid for raw.v0 envelopes
+func ValidateRawStream(stream string) error {
+	switch RawEnvelopeV0Stream(stream) {
+	case Orderbook, Trades, Status:
+		return nil
+	default:
+		return fmt.Errorf("invalid stream: %s", stream)
+	}
+}
+
+// ValidateVenueStream checks that a venue is registered and publishes a raw stream
+func ValidateVenueStream(venue VenueID, stream RawEnvelopeV0Stream) error {
+	if err := ValidateRawStream(string(stream)); err != nil {
+		return err
+	}
+	descriptor, ok := venues.Lookup(string(venue))
+	if !ok {
+		return fmt.Errorf("invalid venue: %s", venue)
+	}
+	if !descriptor.SupportsStream(string(stream)) {
+		return fmt.Errorf("venue %s does not publish stream %s", venue, stream)
+	}
+	return nil
+}
+
+// RawTopic builds the raw.venue.{venue}.{stream} topic for a venue and stream
+func RawTopic(venue VenueID, stream RawEnvelopeV0Stream) (string, error) {
+	if err := ValidateVenueStream(venue, stream); err != nil {
+		return "", err
+	}
+	return RawTopicPrefix + string(venue) + "." + string(stream), nil
+}
+
+// ParseRawTopic splits a raw.venue.{venue}.{stream} topic into its venue and stream
+func ParseRawTopic(topic string) (VenueID, RawEnvelopeV0Stream, error) {
+	rest, ok := strings.CutPrefix(topic, RawTopicPrefix)
+	if !ok {
+		return "", "", fmt.Errorf("not a raw venue topic: %s", topic)
+	}
+	venue, stream, ok := strings.Cut(rest, ".")
+	if !ok {
+		return "", "", fmt.Errorf("not a raw venue topic: %s", topic)
+	}
+	if err := ValidateVenueStream(VenueID(venue), RawEnvelopeV0Stream(stream)); err != nil {
+		return "", "", err
+	}
+	return VenueID(venue), RawEnvelopeV0Stream(stream), nil
+}
+
+// TopicMatches reports whether a topic matches a dot-separated subscription
+// pattern. "*" matches exactly one segment and "**" matches zero or more
+// segments, so "raw.venue.*.trades" and "sunday.**" are both valid patterns.
+func TopicMatches(pattern, topic string) bool {
+	return matchSegments(strings.Split(pattern, "."), strings.Split(topic, "."))
+}
+
+func matchSegments(pattern, topic []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case "**":
+			for i := 0; i <= len(topic); i++ {
+				if matchSegments(pattern[1:], topic[i:]) {
+					return true
+				}
+			}
+			return false
+		case "*":
+			if len(topic) == 0 {
+				return false
+			}
+		default:
+			if len(topic) == 0 || pattern[0] != topic[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		topic = topic[1:]
+	}
+	return len(topic) == 0
+}
+
+// TopicsForSchema returns the topics for a schema from the embedded registry
+func TopicsForSchema(schema EventSchema) []string {
+	return DefaultTopicRegistry().TopicsForSchema(schema)
+}
+
+// SchemaForTopic returns the schema published on a topic from the embedded registry
+func SchemaForTopic(topic string) (EventSchema, bool) {
+	return DefaultTopicRegistry().SchemaForTopic(topic)
+}
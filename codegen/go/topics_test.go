@@ -0,0 +1,192 @@
+package sundayschemas
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
+)
+
+func TestTopicRegistry_SchemaToTopics(t *testing.T) {
+	tests := []struct {
+		schema EventSchema
+		want   []string
+	}{
+		{SchemaMD_TRADE_V1, []string{"md.trades"}},
+		{SchemaMD_ORDERBOOK_DELTA_V1, []string{"md.normalized.orderbook"}},
+		{SchemaRAW_EVENTS_V0, []string{"sunday.events.discovered", "sunday.events.updated", "sunday.events.expired"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.schema), func(t *testing.T) {
+			if got := TopicsForSchema(tt.schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopicsForSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := TopicsForSchema("md.trade.v9"); got != nil {
+		t.Errorf("TopicsForSchema(unknown) = %v, want nil", got)
+	}
+}
+
+func TestTopicRegistry_TopicToSchema(t *testing.T) {
+	tests := []struct {
+		topic  string
+		want   EventSchema
+		wantOK bool
+	}{
+		{"md.trades", SchemaMD_TRADE_V1, true},
+		{"infra.venue_health", SchemaINFRA_VENUE_HEALTH_V1, true},
+		{"sunday.series.expired", SchemaRAW_SERIES_V0, true},
+		{"raw.venue.kalshi.trades", SchemaRAW_V0, true},
+		{"raw.venue.kalshi.status", SchemaRAW_V0, true},
+		{"raw.venue.manifold.trades", "", false},
+		{"raw.venue.kalshi.event_discovery", "", false},
+		{"md.unknown", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			got, ok := SchemaForTopic(tt.topic)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("SchemaForTopic() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTopicRegistry_EveryAllSchemaHasTopics(t *testing.T) {
+	for _, schema := range AllSchemas() {
+		if len(TopicsForSchema(schema)) == 0 {
+			t.Errorf("schema %s has no topics", schema)
+		}
+	}
+}
+
+func TestRawTopic(t *testing.T) {
+	topic, err := RawTopic(VenuePolymarket, Orderbook)
+	if err != nil || topic != "raw.venue.polymarket.orderbook" {
+		t.Errorf("RawTopic() = (%q, %v), want raw.venue.polymarket.orderbook", topic, err)
+	}
+
+	if _, err := RawTopic("manifold", Trades); err == nil {
+		t.Error("RawTopic() expected error for unknown venue, got nil")
+	}
+	if _, err := RawTopic(VenueKalshi, StreamEventDiscovery); err == nil {
+		t.Error("RawTopic() expected error for discovery stream, got nil")
+	}
+
+	if err := venues.Register(venues.Descriptor{ID: "tradesonly", PriceUnit: venues.PriceUnitCents, ContractSize: 1, Streams: []string{"trades"}}); err != nil {
+		t.Fatalf("venues.Register() error = %v", err)
+	}
+	t.Cleanup(func() { venues.Unregister("tradesonly") })
+	if _, err := RawTopic("tradesonly", Trades); err != nil {
+		t.Errorf("RawTopic() error = %v for runtime-registered venue", err)
+	}
+	if _, err := RawTopic("tradesonly", Orderbook); err == nil {
+		t.Error("RawTopic() expected error for stream the venue does not publish, got nil")
+	}
+
+	venue, stream, err := ParseRawTopic("raw.venue.kalshi.trades")
+	if err != nil || venue != VenueKalshi || stream != Trades {
+		t.Errorf("ParseRawTopic() = (%v, %v, %v), want (kalshi, trades, nil)", venue, stream, err)
+	}
+}
+
+func TestTopicRegistry_DiscoveryTopic(t *testing.T) {
+	registry := DefaultTopicRegistry()
+
+	topic, err := registry.DiscoveryTopic(SchemaRAW_EVENTS_V0, Updated)
+	if err != nil || topic != "sunday.events.updated" {
+		t.Errorf("DiscoveryTopic() = (%q, %v), want sunday.events.updated", topic, err)
+	}
+
+	topic, err = registry.DiscoveryTopic(SchemaRAW_CATEGORIES_V0, Expired)
+	if err != nil || topic != "sunday.categories.expired" {
+		t.Errorf("DiscoveryTopic() = (%q, %v), want sunday.categories.expired", topic, err)
+	}
+
+	if _, err := registry.DiscoveryTopic(SchemaMD_TRADE_V1, Discovered); err == nil {
+		t.Error("DiscoveryTopic() expected error for non-discovery schema, got nil")
+	}
+
+	var unknown UnknownSchemaError
+	if _, err := registry.DiscoveryTopic("raw.markets.v0", Discovered); !errors.As(err, &unknown) {
+		t.Errorf("DiscoveryTopic() error = %v, want UnknownSchemaError", err)
+	}
+}
+
+func TestTopicMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{"md.trades", "md.trades", true},
+		{"raw.venue.*.trades", "raw.venue.kalshi.trades", true},
+		{"raw.venue.*.trades", "raw.venue.kalshi.orderbook", false},
+		{"raw.venue.*", "raw.venue.kalshi.trades", false},
+		{"raw.**", "raw.venue.kalshi.trades", true},
+		{"sunday.*.discovered", "sunday.series.discovered", true},
+		{"**.expired", "sunday.events.expired", true},
+		{"insights.**", "insights", true},
+		{"*", "md.trades", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"|"+tt.topic, func(t *testing.T) {
+			if got := TopicMatches(tt.pattern, tt.topic); got != tt.want {
+				t.Errorf("TopicMatches(%q, %q) = %v, want %v", tt.pattern, tt.topic, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopicRegistry_MatchTopics(t *testing.T) {
+	got := DefaultTopicRegistry().MatchTopics("raw.venue.*.status")
+	want := []string{"raw.venue.kalshi.status", "raw.venue.polymarket.status"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchTopics() = %v, want %v", got, want)
+	}
+
+	got = DefaultTopicRegistry().MatchTopics("sunday.*.discovered")
+	want = []string{"sunday.categories.discovered", "sunday.events.discovered", "sunday.series.discovered"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchTopics() = %v, want %v", got, want)
+	}
+}
+
+// With the built-in venues, the raw.venue expansion adds nothing that
+// topics.json does not list
+func TestTopicRegistry_TopicsMatchTopicsJSON(t *testing.T) {
+	var declared []string
+	for _, schema := range AllSchemas() {
+		declared = append(declared, TopicsForSchema(schema)...)
+	}
+	sort.Strings(declared)
+	if got := DefaultTopicRegistry().Topics(); !reflect.DeepEqual(got, declared) {
+		t.Errorf("Topics() = %v, want the topics.json topics %v", got, declared)
+	}
+}
+
+func TestParseTopicRegistry_Conflict(t *testing.T) {
+	_, err := ParseTopicRegistry([]byte(`{"a.v1":{"topic":"shared"},"b.v1":{"topic":"shared"}}`))
+	if err == nil {
+		t.Error("ParseTopicRegistry() expected error for topic mapped to two schemas, got nil")
+	}
+}
+
+func TestEmbeddedTopicsInSync(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("..", "..", "schemas", "topics.json"))
+	if err != nil {
+		t.Fatalf("failed to read topics.json: %v", err)
+	}
+	if string(embeddedTopics) != string(want) {
+		t.Error("topics.json is out of date; run 'npm run generate-go'")
+	}
+}
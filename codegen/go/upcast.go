@@ -0,0 +1,253 @@
+// Package sundayschemas provides schema upcasting for versioned Sunday messages
+package sundayschemas
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VersionLegacy is the version of the compat shapes in compat.go
+// (RawEnvelope, Trade and OrderbookDelta). They carry the same schema
+// identifier as their canonical type, so they are told apart by Go type and
+// only reach the upcasters through UpcastRegistry.Upcast.
+const VersionLegacy = -1
+
+// SchemaVersion splits a schema identifier into its family and version, e.g.
+// "md.trade.v1" is family "md.trade" at version 1
+type SchemaVersion struct {
+	Family  string
+	Version int
+}
+
+// ParseSchemaVersion splits a schema identifier of the form <family>.v<N>
+func ParseSchemaVersion(schema EventSchema) (SchemaVersion, error) {
+	s := string(schema)
+	i := strings.LastIndex(s, ".v")
+	if i <= 0 {
+		return SchemaVersion{}, fmt.Errorf("schema %q has no version suffix", s)
+	}
+	digits := s[i+2:]
+	version, err := strconv.Atoi(digits)
+	if err != nil || version < 0 || strconv.Itoa(version) != digits {
+		return SchemaVersion{}, fmt.Errorf("schema %q has an invalid version suffix", s)
+	}
+	return SchemaVersion{Family: s[:i], Version: version}, nil
+}
+
+// Schema returns the schema identifier, e.g. "md.trade.v1". Legacy versions
+// have no identifier of their own.
+func (v SchemaVersion) Schema() EventSchema {
+	if v.Version == VersionLegacy {
+		return ""
+	}
+	return EventSchema(fmt.Sprintf("%s.v%d", v.Family, v.Version))
+}
+
+func (v SchemaVersion) String() string {
+	if v.Version == VersionLegacy {
+		return v.Family + " (legacy)"
+	}
+	return string(v.Schema())
+}
+
+// UpcastError reports a failed upgrade step
+type UpcastError struct {
+	From, To SchemaVersion
+	Err      error
+}
+
+func (e *UpcastError) Error() string {
+	return fmt.Sprintf("upcast %s to %s: %v", e.From, e.To, e.Err)
+}
+
+func (e *UpcastError) Unwrap() error {
+	return e.Err
+}
+
+type upcastStep struct {
+	to int
+	fn func(any) (any, error)
+}
+
+// UpcastRegistry holds upgrade steps keyed by schema family and version.
+// Steps chain automatically: a message at v1 with steps v1→v2 and v2→v3 is
+// returned at v3. It is safe for concurrent use. The zero value is an empty
+// registry.
+type UpcastRegistry struct {
+	mu    sync.RWMutex
+	steps map[SchemaVersion]upcastStep
+	types map[reflect.Type]SchemaVersion
+}
+
+// NewUpcastRegistry creates an empty registry
+func NewUpcastRegistry() *UpcastRegistry {
+	return &UpcastRegistry{}
+}
+
+// RegisterUpcast adds a step upgrading family from one version to a later
+// one. Each version has at most one outgoing step, so the chain from any
+// version is unambiguous. From and To are the Go types of the message at
+// each version; the step fails with an *UpcastError if the chain hands it
+// another type.
+func RegisterUpcast[From, To any](r *UpcastRegistry, family string, from, to int, fn func(From) (To, error)) error {
+	if family == "" {
+		return fmt.Errorf("upcast has empty schema family")
+	}
+	if from < VersionLegacy || to <= from {
+		return fmt.Errorf("upcast %s from v%d to v%d: target version must be later", family, from, to)
+	}
+	if fn == nil {
+		return fmt.Errorf("upcast %s from v%d to v%d: nil function", family, from, to)
+	}
+	fromType := reflect.TypeOf((*From)(nil)).Elem()
+	toType := reflect.TypeOf((*To)(nil)).Elem()
+	if fromType == toType {
+		return fmt.Errorf("upcast %s from v%d to v%d: versions must have distinct types", family, from, to)
+	}
+	step := upcastStep{to: to, fn: func(v any) (any, error) {
+		in, ok := v.(From)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want %v", v, fromType)
+		}
+		return fn(in)
+	}}
+	return r.register(SchemaVersion{family, from}, SchemaVersion{family, to}, fromType, toType, step)
+}
+
+func (r *UpcastRegistry) register(from, to SchemaVersion, fromType, toType reflect.Type, step upcastStep) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.steps[from]; dup {
+		return fmt.Errorf("upcast from %s is already registered", from)
+	}
+	for t, v := range map[reflect.Type]SchemaVersion{fromType: from, toType: to} {
+		if known, ok := r.types[t]; ok && known != v {
+			return fmt.Errorf("%v is already registered as %s", t, known)
+		}
+	}
+	if r.steps == nil {
+		r.steps = make(map[SchemaVersion]upcastStep)
+		r.types = make(map[reflect.Type]SchemaVersion)
+	}
+	r.steps[from] = step
+	r.types[fromType], r.types[toType] = from, to
+	return nil
+}
+
+func (r *UpcastRegistry) step(v SchemaVersion) (upcastStep, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.steps[v]
+	return s, ok
+}
+
+// Latest returns the version a message at v is upcast to
+func (r *UpcastRegistry) Latest(v SchemaVersion) SchemaVersion {
+	for {
+		s, ok := r.step(v)
+		if !ok {
+			return v
+		}
+		v.Version = s.to
+	}
+}
+
+// Upgrade applies the registered steps to msg, a message at version from,
+// until its family's latest version is reached. It returns the upcast
+// message and its version.
+func (r *UpcastRegistry) Upgrade(from SchemaVersion, msg any) (any, SchemaVersion, error) {
+	for {
+		s, ok := r.step(from)
+		if !ok {
+			return msg, from, nil
+		}
+		to := SchemaVersion{Family: from.Family, Version: s.to}
+		out, err := s.fn(msg)
+		if err != nil {
+			return nil, from, &UpcastError{From: from, To: to, Err: err}
+		}
+		msg, from = out, to
+	}
+}
+
+// Upcast upgrades an in-process message, such as a legacy compat struct, to
+// the latest version of its schema. The message's version is found from its
+// Go type, which must appear in a registered step.
+func (r *UpcastRegistry) Upcast(msg any) (any, EventSchema, error) {
+	r.mu.RLock()
+	from, ok := r.types[reflect.TypeOf(msg)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, "", fmt.Errorf("no upcast registered for %T", msg)
+	}
+	out, version, err := r.Upgrade(from, msg)
+	if err != nil {
+		return nil, from.Schema(), err
+	}
+	return out, version.Schema(), nil
+}
+
+// Decode is like the package-level Decode with this registry's upcasts
+func (r *UpcastRegistry) Decode(data []byte) (any, EventSchema, error) {
+	v, schema, err := decode(data)
+	if err != nil {
+		return nil, schema, err
+	}
+	version, err := ParseSchemaVersion(schema)
+	if err != nil {
+		return v, schema, nil
+	}
+	v, version, err = r.Upgrade(version, v)
+	if err != nil {
+		return nil, schema, err
+	}
+	return v, version.Schema(), nil
+}
+
+var (
+	defaultUpcasters     *UpcastRegistry
+	defaultUpcastersErr  error
+	defaultUpcastersOnce sync.Once
+)
+
+// DefaultUpcasters returns the registry Decode applies. It upcasts the
+// legacy RawEnvelope, Trade and OrderbookDelta shapes into RawEnvelopeV0,
+// NormalizedTradeV1 and NormalizedOrderBookDeltaV1; steps registered on it
+// are applied by every later Decode. A registration failure is a build
+// defect and panics.
+func DefaultUpcasters() *UpcastRegistry {
+	defaultUpcastersOnce.Do(func() {
+		defaultUpcasters, defaultUpcastersErr = newDefaultUpcasters()
+	})
+	if defaultUpcastersErr != nil {
+		panic(defaultUpcastersErr)
+	}
+	return defaultUpcasters
+}
+
+func newDefaultUpcasters() (*UpcastRegistry, error) {
+	r := NewUpcastRegistry()
+	family := func(schema EventSchema) string {
+		v, _ := ParseSchemaVersion(schema)
+		return v.Family
+	}
+	if err := RegisterUpcast(r, family(SchemaRAW_V0), VersionLegacy, 0, RawEnvelope.ConvertToRawEnvelopeV0); err != nil {
+		return nil, err
+	}
+	if err := RegisterUpcast(r, family(SchemaMD_TRADE_V1), VersionLegacy, 1, Trade.ConvertToNormalizedTradeV1); err != nil {
+		return nil, err
+	}
+	if err := RegisterUpcast(r, family(SchemaMD_ORDERBOOK_DELTA_V1), VersionLegacy, 1, OrderbookDelta.ConvertToNormalizedOrderBookDeltaV1); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Upcast upgrades an in-process message, such as a legacy Trade, to the
+// latest version of its schema using DefaultUpcasters
+func Upcast(msg any) (any, EventSchema, error) {
+	return DefaultUpcasters().Upcast(msg)
+}
@@ -0,0 +1,198 @@
+package sundayschemas
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSchemaVersion(t *testing.T) {
+	tests := []struct {
+		schema EventSchema
+		want   SchemaVersion
+	}{
+		{SchemaMD_TRADE_V1, SchemaVersion{"md.trade", 1}},
+		{SchemaRAW_V0, SchemaVersion{"raw", 0}},
+		{SchemaRAW_EVENTS_V0, SchemaVersion{"raw.events", 0}},
+		{"insights.whales.lite.v12", SchemaVersion{"insights.whales.lite", 12}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSchemaVersion(tt.schema)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSchemaVersion(%q) = %+v, %v, want %+v", tt.schema, got, err, tt.want)
+		}
+		if got.Schema() != tt.schema {
+			t.Errorf("Schema() = %q, want %q", got.Schema(), tt.schema)
+		}
+	}
+	for _, schema := range []EventSchema{"", "md.trade", ".v1", "md.trade.v", "md.trade.v01", "md.trade.v-1", "md.trade.vx"} {
+		if _, err := ParseSchemaVersion(schema); err == nil {
+			t.Errorf("ParseSchemaVersion(%q) error = nil, want error", schema)
+		}
+	}
+	for _, schema := range AllSchemas() {
+		if _, err := ParseSchemaVersion(schema); err != nil {
+			t.Errorf("ParseSchemaVersion(%q) error = %v", schema, err)
+		}
+	}
+}
+
+func TestUpcast_Legacy(t *testing.T) {
+	notional := 10.5
+	v, schema, err := Upcast(Trade{InstrumentID: "pm_x", VenueID: "kalshi", TsMs: 1000, Side: "buy", Prob: 0.5, Size: 21, NotionalUsd: &notional})
+	if err != nil {
+		t.Fatalf("Upcast(Trade) error = %v", err)
+	}
+	trade, ok := v.(NormalizedTradeV1)
+	if !ok || schema != SchemaMD_TRADE_V1 || trade.Schema != MdTradeV1 || trade.TsMS != 1000 || *trade.NotionalUsd != 10.5 {
+		t.Errorf("Upcast(Trade) = %+v (%s)", v, schema)
+	}
+
+	v, schema, err = Upcast(NewRawEnvelope("kalshi", "trades", "PRES-28", time.UnixMilli(1000), map[string]interface{}{"k": "v"}))
+	if env, ok := v.(RawEnvelopeV0); err != nil || !ok || schema != SchemaRAW_V0 || env.TsEventMS != 1000 {
+		t.Errorf("Upcast(RawEnvelope) = %+v (%s), %v", v, schema, err)
+	}
+
+	delta := OrderbookDelta{InstrumentID: "pm_x", VenueID: "polymarket", Seq: 7,
+		Bids: []PriceLevel{{Price: 0.5, Size: 10}, {Price: 0.4, Size: 5}},
+		Asks: []PriceLevel{{Price: 0.6, Size: 3}}}
+	v, schema, err = Upcast(delta)
+	if book, ok := v.(NormalizedOrderBookDeltaV1); err != nil || !ok || schema != SchemaMD_ORDERBOOK_DELTA_V1 || book.Seq != 7 || len(book.Bids) != 2 {
+		t.Errorf("Upcast(OrderbookDelta) = %+v (%s), %v", v, schema, err)
+	}
+
+	delta.Bids = []PriceLevel{{Price: 0.4, Size: 5}, {Price: 0.5, Size: 10}}
+	_, _, err = Upcast(delta)
+	var upErr *UpcastError
+	if !errors.As(err, &upErr) || upErr.From.Version != VersionLegacy || upErr.To != (SchemaVersion{"md.orderbook.delta", 1}) {
+		t.Errorf("Upcast(unordered bids) error = %v, want *UpcastError", err)
+	}
+
+	if _, _, err := Upcast(Trade{VenueID: "manifold", Side: "buy"}); err == nil {
+		t.Error("Upcast(Trade) with an unknown venue should fail")
+	}
+	if _, _, err := Upcast(struct{}{}); err == nil {
+		t.Error("Upcast() of an unregistered type should fail")
+	}
+}
+
+// tradeV2 and tradeV3 stand in for future md.trade versions
+type tradeV2 struct {
+	NormalizedTradeV1
+	Venue string
+}
+
+type tradeV3 struct {
+	tradeV2
+	Notional float64
+}
+
+func newTestUpcasters(t *testing.T) *UpcastRegistry {
+	t.Helper()
+	r, err := newDefaultUpcasters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Registered out of order: chaining follows versions, not registration
+	if err := RegisterUpcast(r, "md.trade", 2, 3, func(t tradeV2) (tradeV3, error) {
+		v3 := tradeV3{tradeV2: t}
+		if t.NotionalUsd != nil {
+			v3.Notional = *t.NotionalUsd
+		}
+		return v3, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterUpcast(r, "md.trade", 1, 2, func(t NormalizedTradeV1) (tradeV2, error) {
+		return tradeV2{NormalizedTradeV1: t, Venue: string(t.VenueID)}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestUpcastRegistry_DecodeChains(t *testing.T) {
+	r := newTestUpcasters(t)
+	data := loadExample(t, filepath.Join("..", "..", "schemas", "examples", "md.trade.buy.example.json"))
+
+	v, schema, err := r.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	trade, ok := v.(tradeV3)
+	if !ok || schema != "md.trade.v3" {
+		t.Fatalf("Decode() = %T (%s), want tradeV3 (md.trade.v3)", v, schema)
+	}
+	if trade.Venue != string(trade.VenueID) || trade.NotionalUsd == nil || trade.Notional != *trade.NotionalUsd {
+		t.Errorf("Decode() = %+v", trade)
+	}
+	if latest := r.Latest(SchemaVersion{"md.trade", VersionLegacy}); latest.Version != 3 {
+		t.Errorf("Latest() = %s, want md.trade.v3", latest)
+	}
+
+	// Legacy values chain through every version
+	v, schema, err = r.Upcast(Trade{InstrumentID: "pm_x", VenueID: "kalshi", Side: "sell", Prob: 0.2, Size: 1})
+	if _, ok := v.(tradeV3); err != nil || !ok || schema != "md.trade.v3" {
+		t.Errorf("Upcast(Trade) = %T (%s), %v", v, schema, err)
+	}
+
+	// Families without steps decode unchanged
+	v, schema, err = r.Decode(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "insights.movers.example.json")))
+	if _, ok := v.(MoversV1); err != nil || !ok || schema != SchemaINSIGHTS_MOVERS_V1 {
+		t.Errorf("Decode(movers) = %T (%s), %v", v, schema, err)
+	}
+}
+
+func TestUpcastRegistry_StepErrors(t *testing.T) {
+	r := NewUpcastRegistry()
+	boom := errors.New("boom")
+	if err := RegisterUpcast(r, "md.trade", 1, 2, func(t NormalizedTradeV1) (tradeV2, error) {
+		return tradeV2{}, boom
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_, schema, err := r.Decode(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "md.trade.sell.example.json")))
+	var upErr *UpcastError
+	if !errors.As(err, &upErr) || !errors.Is(err, boom) || schema != SchemaMD_TRADE_V1 {
+		t.Errorf("Decode() = %s, %v, want an UpcastError wrapping boom", schema, err)
+	}
+
+	// A type belongs to one version
+	if err := RegisterUpcast(r, "md.orderbook.delta", 1, 2, func(t NormalizedTradeV1) (tradeV2, error) {
+		return tradeV2{}, nil
+	}); err == nil {
+		t.Error("RegisterUpcast() should reject a type already registered at another version")
+	}
+
+	// A step whose input type does not match the decoded type fails, not panics
+	if err := RegisterUpcast(r, "insights.movers", 1, 2, func(a ArbitrageLiteV1) (tradeV3, error) {
+		return tradeV3{}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = r.Decode(loadExample(t, filepath.Join("..", "..", "schemas", "examples", "insights.movers.example.json")))
+	if !errors.As(err, &upErr) || upErr.From != (SchemaVersion{"insights.movers", 1}) {
+		t.Errorf("Decode() error = %v, want an UpcastError from insights.movers.v1", err)
+	}
+}
+
+func TestRegisterUpcast_Validation(t *testing.T) {
+	r := NewUpcastRegistry()
+	noop := func(t NormalizedTradeV1) (tradeV2, error) { return tradeV2{}, nil }
+	if err := RegisterUpcast(r, "md.trade", 2, 2, noop); err == nil {
+		t.Error("RegisterUpcast() should reject a step that does not move forward")
+	}
+	if err := RegisterUpcast(r, "", 1, 2, noop); err == nil {
+		t.Error("RegisterUpcast() should reject an empty family")
+	}
+	if err := RegisterUpcast(r, "md.trade", 1, 2, func(t Trade) (Trade, error) { return t, nil }); err == nil {
+		t.Error("RegisterUpcast() should reject identical From and To types")
+	}
+	if err := RegisterUpcast(r, "md.trade", 1, 2, noop); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterUpcast(r, "md.trade", 1, 3, func(t NormalizedTradeV1) (tradeV3, error) { return tradeV3{}, nil }); err == nil {
+		t.Error("RegisterUpcast() should reject a second step from the same version")
+	}
+}
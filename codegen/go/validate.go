@@ -0,0 +1,255 @@
+// Package sundayschemas provides semantic validation for market-data, insight and infra messages
+package sundayschemas
+
+import (
+	"fmt"
+	"math"
+)
+
+// ValidationError reports a field that breaks a schema rule
+type ValidationError struct {
+	Schema  EventSchema
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid %s: %s", e.Schema, e.Field, e.Message)
+}
+
+func invalidField(schema EventSchema, field, format string, args ...any) error {
+	return ValidationError{Schema: schema, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// MoversDeltaBpsTolerance is how far MoversV1.DeltaBps may differ from
+// (ProbNow - ProbPrev) in basis points, allowing for rounding by producers
+const MoversDeltaBpsTolerance = 1
+
+// Validate checks an md.trade.v1 message: prob in [0, 1], size > 0 and a
+// registered venue
+func (t NormalizedTradeV1) Validate() error {
+	const schema = SchemaMD_TRADE_V1
+	if t.Schema != MdTradeV1 {
+		return invalidField(schema, "schema", "got %q", t.Schema)
+	}
+	if err := checkInstrumentAndVenue(schema, t.InstrumentID, t.VenueID); err != nil {
+		return err
+	}
+	if t.TsMS < 0 {
+		return invalidField(schema, "ts_ms", "must be >= 0")
+	}
+	if err := ValidateDirection(string(t.Side)); err != nil {
+		return invalidField(schema, "side", "%q is not buy or sell", t.Side)
+	}
+	if !isProbability(t.Prob) {
+		return invalidField(schema, "prob", "%v outside [0, 1]", t.Prob)
+	}
+	if !(t.Size > 0) || math.IsInf(t.Size, 0) {
+		return invalidField(schema, "size", "%v must be a finite number > 0", t.Size)
+	}
+	if t.NotionalUsd != nil && !isNonNegative(*t.NotionalUsd) {
+		return invalidField(schema, "notional_usd", "%v must be a finite number >= 0", *t.NotionalUsd)
+	}
+	return nil
+}
+
+// Validate checks an md.orderbook.delta.v1 message. Bids must be in strictly
+// descending and asks in strictly ascending price order, as the normalizer
+// emits them.
+func (d NormalizedOrderBookDeltaV1) Validate() error {
+	const schema = SchemaMD_ORDERBOOK_DELTA_V1
+	if d.Schema != MdOrderbookDeltaV1 {
+		return invalidField(schema, "schema", "got %q", d.Schema)
+	}
+	if err := checkInstrumentAndVenue(schema, d.InstrumentID, d.VenueID); err != nil {
+		return err
+	}
+	if d.Seq < 0 {
+		return invalidField(schema, "seq", "must be >= 0")
+	}
+	if d.TsMS < 0 {
+		return invalidField(schema, "ts_ms", "must be >= 0")
+	}
+	if err := ValidateBids(d.Bids); err != nil {
+		return invalidField(schema, "bids", "%v", err)
+	}
+	if err := ValidateAsks(d.Asks); err != nil {
+		return invalidField(schema, "asks", "%v", err)
+	}
+	return nil
+}
+
+// Validate checks an infra.venue_health.v1 message. last_event_ts_ms is the
+// venue's clock and observed_at_ms the observer's, so clock skew may put the
+// last event after the observation; that is not an error.
+func (h VenueHealthV1) Validate() error {
+	const schema = SchemaINFRA_VENUE_HEALTH_V1
+	if h.Schema != InfraVenueHealthV1 {
+		return invalidField(schema, "schema", "got %q", h.Schema)
+	}
+	if err := ValidateVenue(string(h.VenueID)); err != nil {
+		return invalidField(schema, "venue_id", "%v", err)
+	}
+	switch h.Status {
+	case Connected, Degraded, Stale:
+	default:
+		return invalidField(schema, "status", "%q is not CONNECTED, DEGRADED or STALE", h.Status)
+	}
+	if h.LastEventTsMS < 0 {
+		return invalidField(schema, "last_event_ts_ms", "must be >= 0")
+	}
+	if h.ObservedAtMS < 0 {
+		return invalidField(schema, "observed_at_ms", "must be >= 0")
+	}
+	if h.MessagesPerSecond != nil && !isNonNegative(*h.MessagesPerSecond) {
+		return invalidField(schema, "messages_per_second", "%v must be a finite number >= 0", *h.MessagesPerSecond)
+	}
+	if h.StalenessSeconds != nil && !isNonNegative(*h.StalenessSeconds) {
+		return invalidField(schema, "staleness_seconds", "%v must be a finite number >= 0", *h.StalenessSeconds)
+	}
+	return nil
+}
+
+// Validate checks an insights.arb.lite.v1 message. An arbitrage needs two
+// different venues.
+func (a ArbitrageLiteV1) Validate() error {
+	const schema = SchemaINSIGHTS_ARB_LITE_V1
+	if a.Schema != InsightsArbLiteV1 {
+		return invalidField(schema, "schema", "got %q", a.Schema)
+	}
+	if a.InstrumentID == "" {
+		return invalidField(schema, "instrument_id", "required field is empty")
+	}
+	if err := ValidateVenue(string(a.LongVenue)); err != nil {
+		return invalidField(schema, "long_venue", "%v", err)
+	}
+	if err := ValidateVenue(string(a.ShortVenue)); err != nil {
+		return invalidField(schema, "short_venue", "%v", err)
+	}
+	if a.LongVenue == a.ShortVenue {
+		return invalidField(schema, "short_venue", "same as long_venue %q", a.LongVenue)
+	}
+	if math.IsNaN(a.EdgeBps) || math.IsInf(a.EdgeBps, 0) {
+		return invalidField(schema, "edge_bps", "%v is not a finite number", a.EdgeBps)
+	}
+	switch a.DepthTier {
+	case S, M, L:
+	default:
+		return invalidField(schema, "depth_tier", "%q is not S, M or L", a.DepthTier)
+	}
+	if a.PersistenceMS < 0 {
+		return invalidField(schema, "persistence_ms", "must be >= 0")
+	}
+	if a.LastSeenMS < 0 {
+		return invalidField(schema, "last_seen_ms", "must be >= 0")
+	}
+	return nil
+}
+
+// Validate checks an insights.movers.v1 message. DeltaBps must match
+// ProbNow - ProbPrev to within MoversDeltaBpsTolerance.
+func (m MoversV1) Validate() error {
+	const schema = SchemaINSIGHTS_MOVERS_V1
+	if m.Schema != InsightsMoversV1 {
+		return invalidField(schema, "schema", "got %q", m.Schema)
+	}
+	if m.InstrumentID == "" {
+		return invalidField(schema, "instrument_id", "required field is empty")
+	}
+	if err := checkWindow(schema, m.Window); err != nil {
+		return err
+	}
+	if !isProbability(m.ProbNow) {
+		return invalidField(schema, "prob_now", "%v outside [0, 1]", m.ProbNow)
+	}
+	if !isProbability(m.ProbPrev) {
+		return invalidField(schema, "prob_prev", "%v outside [0, 1]", m.ProbPrev)
+	}
+	want := int64(math.Round((m.ProbNow - m.ProbPrev) * 10000))
+	if diff := m.DeltaBps - want; diff > MoversDeltaBpsTolerance || diff < -MoversDeltaBpsTolerance {
+		return invalidField(schema, "delta_bps", "%d does not match prob_now - prob_prev (%d bps)", m.DeltaBps, want)
+	}
+	if m.ImbalanceIndex < 0 || m.ImbalanceIndex > 100 {
+		return invalidField(schema, "imbalance_index", "%d outside [0, 100]", m.ImbalanceIndex)
+	}
+	if m.TsMS < 0 {
+		return invalidField(schema, "ts_ms", "must be >= 0")
+	}
+	return nil
+}
+
+// Validate checks an insights.unusual.v1 message
+func (u UnusualActivityV1) Validate() error {
+	const schema = SchemaINSIGHTS_UNUSUAL_V1
+	if u.Schema != InsightsUnusualV1 {
+		return invalidField(schema, "schema", "got %q", u.Schema)
+	}
+	if u.InstrumentID == "" {
+		return invalidField(schema, "instrument_id", "required field is empty")
+	}
+	switch u.Metric {
+	case Volume, Volatility:
+	default:
+		return invalidField(schema, "metric", "%q is not volume or volatility", u.Metric)
+	}
+	if err := checkWindow(schema, u.Window); err != nil {
+		return err
+	}
+	if math.IsNaN(u.Zscore) || math.IsInf(u.Zscore, 0) {
+		return invalidField(schema, "zscore", "%v is not a finite number", u.Zscore)
+	}
+	if u.TsMS < 0 {
+		return invalidField(schema, "ts_ms", "must be >= 0")
+	}
+	return nil
+}
+
+// Validate checks an insights.whales.lite.v1 message
+func (w WhaleFlowsLiteV1) Validate() error {
+	const schema = SchemaINSIGHTS_WHALES_LITE_V1
+	if w.Schema != InsightsWhalesLiteV1 {
+		return invalidField(schema, "schema", "got %q", w.Schema)
+	}
+	if err := checkInstrumentAndVenue(schema, w.InstrumentID, w.VenueID); err != nil {
+		return err
+	}
+	switch w.Impact {
+	case Low, Med, High:
+	default:
+		return invalidField(schema, "impact", "%q is not LOW, MED or HIGH", w.Impact)
+	}
+	if err := ValidateDirection(string(w.Direction)); err != nil {
+		return invalidField(schema, "direction", "%q is not buy or sell", w.Direction)
+	}
+	if w.TsMS < 0 {
+		return invalidField(schema, "ts_ms", "must be >= 0")
+	}
+	return nil
+}
+
+func checkInstrumentAndVenue(schema EventSchema, instrumentID string, venue VenueID) error {
+	if instrumentID == "" {
+		return invalidField(schema, "instrument_id", "required field is empty")
+	}
+	if err := ValidateVenue(string(venue)); err != nil {
+		return invalidField(schema, "venue_id", "%v", err)
+	}
+	return nil
+}
+
+func checkWindow(schema EventSchema, window Window) error {
+	switch window {
+	case The1H, The24H:
+		return nil
+	default:
+		return invalidField(schema, "window", "%q is not 1h or 24h", window)
+	}
+}
+
+func isProbability(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+func isNonNegative(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 0)
+}
@@ -0,0 +1,110 @@
+package sundayschemas
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate_Examples(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "schemas", "examples", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked := 0
+	for _, path := range paths {
+		v, _, err := Decode(loadExample(t, path))
+		if err != nil {
+			t.Fatalf("Decode(%s) error = %v", path, err)
+		}
+		validator, ok := v.(interface{ Validate() error })
+		if !ok {
+			continue
+		}
+		checked++
+		if err := validator.Validate(); err != nil {
+			t.Errorf("%s: Validate() error = %v", filepath.Base(path), err)
+		}
+	}
+	if checked < 10 {
+		t.Errorf("validated %d examples, want every md, insight and infra example", checked)
+	}
+}
+
+func TestValidate_Rejects(t *testing.T) {
+	trade := func() NormalizedTradeV1 {
+		return NormalizedTradeV1{Schema: MdTradeV1, InstrumentID: "i", VenueID: VenueKalshi, TsMS: 1, Side: Buy, Prob: 0.5, Size: 10}
+	}
+	book := func() NormalizedOrderBookDeltaV1 {
+		return NormalizedOrderBookDeltaV1{Schema: MdOrderbookDeltaV1, InstrumentID: "i", VenueID: VenueKalshi, Seq: 1, TsMS: 1,
+			Bids: []PriceLevel{{0.5, 1}, {0.4, 1}}, Asks: []PriceLevel{{0.6, 1}, {0.7, 1}}}
+	}
+	health := func() VenueHealthV1 {
+		return VenueHealthV1{Schema: InfraVenueHealthV1, VenueID: VenueKalshi, Status: Connected, LastEventTsMS: 900, ObservedAtMS: 1000}
+	}
+	arb := func() ArbitrageLiteV1 {
+		return ArbitrageLiteV1{Schema: InsightsArbLiteV1, InstrumentID: "i", LongVenue: VenuePolymarket, ShortVenue: VenueKalshi, EdgeBps: 40, DepthTier: M}
+	}
+	movers := func() MoversV1 {
+		return MoversV1{Schema: InsightsMoversV1, InstrumentID: "i", Window: The1H, ProbNow: 0.68, ProbPrev: 0.55, DeltaBps: 1300, ImbalanceIndex: 50}
+	}
+	unusual := func() UnusualActivityV1 {
+		return UnusualActivityV1{Schema: InsightsUnusualV1, InstrumentID: "i", Metric: Volume, Window: The24H, Zscore: 3}
+	}
+	whale := func() WhaleFlowsLiteV1 {
+		return WhaleFlowsLiteV1{Schema: InsightsWhalesLiteV1, InstrumentID: "i", VenueID: VenueKalshi, Impact: High, Direction: Sell}
+	}
+	negative := -1.0
+
+	tests := []struct {
+		name  string
+		msg   interface{ Validate() error }
+		field string
+	}{
+		{"trade wrong schema", func() NormalizedTradeV1 { m := trade(); m.Schema = "md.trade.v2"; return m }(), "schema"},
+		{"trade unknown venue", func() NormalizedTradeV1 { m := trade(); m.VenueID = "nowhere"; return m }(), "venue_id"},
+		{"trade prob above one", func() NormalizedTradeV1 { m := trade(); m.Prob = 1.01; return m }(), "prob"},
+		{"trade prob NaN", func() NormalizedTradeV1 { m := trade(); m.Prob = math.NaN(); return m }(), "prob"},
+		{"trade zero size", func() NormalizedTradeV1 { m := trade(); m.Size = 0; return m }(), "size"},
+		{"trade negative ts", func() NormalizedTradeV1 { m := trade(); m.TsMS = -1; return m }(), "ts_ms"},
+		{"trade negative notional", func() NormalizedTradeV1 { m := trade(); m.NotionalUsd = &negative; return m }(), "notional_usd"},
+		{"trade bad side", func() NormalizedTradeV1 { m := trade(); m.Side = "hold"; return m }(), "side"},
+		{"book unsorted bids", func() NormalizedOrderBookDeltaV1 { m := book(); m.Bids = []PriceLevel{{0.4, 1}, {0.5, 1}}; return m }(), "bids"},
+		{"book ask above one", func() NormalizedOrderBookDeltaV1 { m := book(); m.Asks = []PriceLevel{{1.2, 1}}; return m }(), "asks"},
+		{"book negative seq", func() NormalizedOrderBookDeltaV1 { m := book(); m.Seq = -1; return m }(), "seq"},
+		{"health bad status", func() VenueHealthV1 { m := health(); m.Status = "UP"; return m }(), "status"},
+		{"health negative last event", func() VenueHealthV1 { m := health(); m.LastEventTsMS = -1; return m }(), "last_event_ts_ms"},
+		{"health negative staleness", func() VenueHealthV1 { m := health(); m.StalenessSeconds = &negative; return m }(), "staleness_seconds"},
+		{"arb same venue", func() ArbitrageLiteV1 { m := arb(); m.ShortVenue = VenuePolymarket; return m }(), "short_venue"},
+		{"arb bad tier", func() ArbitrageLiteV1 { m := arb(); m.DepthTier = "XL"; return m }(), "depth_tier"},
+		{"arb negative persistence", func() ArbitrageLiteV1 { m := arb(); m.PersistenceMS = -5; return m }(), "persistence_ms"},
+		{"movers delta mismatch", func() MoversV1 { m := movers(); m.DeltaBps = 1200; return m }(), "delta_bps"},
+		{"movers delta sign", func() MoversV1 { m := movers(); m.DeltaBps = -1300; return m }(), "delta_bps"},
+		{"movers prob_prev", func() MoversV1 { m := movers(); m.ProbPrev = -0.1; return m }(), "prob_prev"},
+		{"movers imbalance", func() MoversV1 { m := movers(); m.ImbalanceIndex = 101; return m }(), "imbalance_index"},
+		{"movers bad window", func() MoversV1 { m := movers(); m.Window = "7d"; return m }(), "window"},
+		{"unusual bad metric", func() UnusualActivityV1 { m := unusual(); m.Metric = "spread"; return m }(), "metric"},
+		{"unusual infinite zscore", func() UnusualActivityV1 { m := unusual(); m.Zscore = math.Inf(1); return m }(), "zscore"},
+		{"whale bad impact", func() WhaleFlowsLiteV1 { m := whale(); m.Impact = "HUGE"; return m }(), "impact"},
+		{"whale empty instrument", func() WhaleFlowsLiteV1 { m := whale(); m.InstrumentID = ""; return m }(), "instrument_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verr ValidationError
+			if err := tt.msg.Validate(); !errors.As(err, &verr) || verr.Field != tt.field {
+				t.Errorf("Validate() error = %v, want ValidationError on %s", err, tt.field)
+			}
+		})
+	}
+
+	// Venue clock ahead of the observer's
+	skewed := health()
+	skewed.LastEventTsMS = 2000
+
+	for _, msg := range []interface{ Validate() error }{trade(), book(), health(), skewed, arb(), movers(), unusual(), whale()} {
+		if err := msg.Validate(); err != nil {
+			t.Errorf("%T.Validate() error = %v", msg, err)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Package venues provides the runtime venue registry for Sunday platform schemas
+//
+// venues.json is copied from schemas/registries/venues.json by 'npm run generate-go'.
+// The registry is the single source of venue knowledge for the sundayschemas,
+// discovery and api packages; venues registered at runtime are accepted by
+// their validators, including sundayschemas.ValidateJSON, without regenerating
+// code. JSON Schema validators outside this module only know the venues the
+// schemas enumerate.
+package venues
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+//go:embed venues.json
+var embeddedVenues []byte
+
+// PriceUnit is the unit a venue quotes native prices in
+type PriceUnit string
+
+const (
+	// PriceUnitProbability prices are quoted as probabilities in [0, 1]
+	PriceUnitProbability PriceUnit = "probability"
+	// PriceUnitCents prices are quoted in cents in [0, 100]
+	PriceUnitCents PriceUnit = "cents"
+)
+
+// Stream names a raw venue stream
+const (
+	StreamOrderbook = "orderbook"
+	StreamTrades    = "trades"
+	StreamStatus    = "status"
+)
+
+// Descriptor describes a venue
+type Descriptor struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"display_name,omitempty"`
+	PriceUnit   PriceUnit `json:"price_unit,omitempty"`
+	// ContractSize is the USD payout of one contract on resolution
+	ContractSize float64  `json:"contract_size,omitempty"`
+	Streams      []string `json:"streams,omitempty"`
+}
+
+// SupportsStream reports whether the venue publishes a raw stream
+func (d Descriptor) SupportsStream(stream string) bool {
+	for _, s := range d.Streams {
+		if s == stream {
+			return true
+		}
+	}
+	return false
+}
+
+// ToProbability converts a native price to a probability
+func (d Descriptor) ToProbability(price float64) (float64, error) {
+	switch d.PriceUnit {
+	case PriceUnitProbability:
+		return price, nil
+	case PriceUnitCents:
+		return price / 100, nil
+	default:
+		return 0, fmt.Errorf("venue %s has unknown price unit %q", d.ID, d.PriceUnit)
+	}
+}
+
+// Validate checks that a descriptor is complete
+func (d Descriptor) Validate() error {
+	if d.ID == "" {
+		return fmt.Errorf("venue descriptor has empty id")
+	}
+	switch d.PriceUnit {
+	case PriceUnitProbability, PriceUnitCents:
+	default:
+		return fmt.Errorf("venue %s: invalid price unit %q", d.ID, d.PriceUnit)
+	}
+	if d.ContractSize <= 0 {
+		return fmt.Errorf("venue %s: contract size must be > 0", d.ID)
+	}
+	return nil
+}
+
+// builtinDescriptors returns the descriptors in the embedded venues.json,
+// keyed by ID. A parse failure is a build defect and panics.
+var builtinDescriptors = sync.OnceValue(func() map[string]Descriptor {
+	descriptors, err := parseEntries(embeddedVenues, nil)
+	if err != nil {
+		panic(fmt.Errorf("embedded venues.json: %w", err))
+	}
+	out := make(map[string]Descriptor, len(descriptors))
+	for _, d := range descriptors {
+		out[d.ID] = d
+	}
+	return out
+})
+
+// Registry holds venue descriptors in registration order. It is safe for
+// concurrent use. The zero value is an empty registry.
+type Registry struct {
+	mu    sync.RWMutex
+	byID  map[string]Descriptor
+	order []string
+}
+
+// NewRegistry creates a registry populated with the given descriptors
+func NewRegistry(descriptors ...Descriptor) (*Registry, error) {
+	r := &Registry{}
+	for _, d := range descriptors {
+		if err := r.Register(d); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// ParseRegistry parses a venues.json document: a list of descriptor objects,
+// e.g.
+//
+//	[{"id": "manifold", "display_name": "Manifold", "price_unit": "probability", "contract_size": 1, "streams": ["trades"]}]
+//
+// An entry may also be the bare ID of a venue in the embedded venues.json, or
+// a descriptor object for one that omits fields; the embedded values fill the
+// gaps.
+func ParseRegistry(data []byte) (*Registry, error) {
+	descriptors, err := parseEntries(data, builtinDescriptors())
+	if err != nil {
+		return nil, err
+	}
+	r := &Registry{}
+	for i, d := range descriptors {
+		if err := r.Register(d); err != nil {
+			return nil, fmt.Errorf("venues[%d]: %w", i, err)
+		}
+	}
+	return r, nil
+}
+
+// parseEntries decodes venues.json entries, filling bare IDs and omitted
+// fields from fallback
+func parseEntries(data []byte, fallback map[string]Descriptor) ([]Descriptor, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse venues: %w", err)
+	}
+
+	out := make([]Descriptor, len(entries))
+	for i, raw := range entries {
+		d, err := parseEntry(raw, fallback)
+		if err != nil {
+			return nil, fmt.Errorf("venues[%d]: %w", i, err)
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+func parseEntry(raw json.RawMessage, fallback map[string]Descriptor) (Descriptor, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		var id string
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return Descriptor{}, err
+		}
+		d, ok := fallback[id]
+		if !ok {
+			return Descriptor{}, fmt.Errorf("venue %s has no descriptor", id)
+		}
+		return d, nil
+	}
+
+	var d Descriptor
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return Descriptor{}, err
+	}
+	if b, ok := fallback[d.ID]; ok {
+		if d.DisplayName == "" {
+			d.DisplayName = b.DisplayName
+		}
+		if d.PriceUnit == "" {
+			d.PriceUnit = b.PriceUnit
+		}
+		if d.ContractSize == 0 {
+			d.ContractSize = b.ContractSize
+		}
+		if d.Streams == nil {
+			d.Streams = b.Streams
+		}
+	}
+	return d, nil
+}
+
+// Register adds a venue. Registering an ID twice is an error.
+func (r *Registry) Register(d Descriptor) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if d.DisplayName == "" {
+		d.DisplayName = d.ID
+	}
+	d.Streams = append([]string(nil), d.Streams...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.byID[d.ID]; dup {
+		return fmt.Errorf("venue %s is already registered", d.ID)
+	}
+	if r.byID == nil {
+		r.byID = make(map[string]Descriptor)
+	}
+	r.byID[d.ID] = d
+	r.order = append(r.order, d.ID)
+	return nil
+}
+
+// Unregister removes a venue and reports whether it was registered
+func (r *Registry) Unregister(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[id]; !ok {
+		return false
+	}
+	delete(r.byID, id)
+	for i, o := range r.order {
+		if o == id {
+			r.order = append(r.order[:i:i], r.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Lookup returns the descriptor for a venue
+func (r *Registry) Lookup(id string) (Descriptor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.byID[id]
+	if !ok {
+		return Descriptor{}, false
+	}
+	d.Streams = append([]string(nil), d.Streams...)
+	return d, true
+}
+
+// IsValid reports whether a venue is registered
+func (r *Registry) IsValid(id string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.byID[id]
+	return ok
+}
+
+// Validate returns an error if a venue is not registered
+func (r *Registry) Validate(id string) error {
+	if !r.IsValid(id) {
+		return fmt.Errorf("invalid venue: %s", id)
+	}
+	return nil
+}
+
+// IDs returns every registered venue ID in registration order
+func (r *Registry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.order...)
+}
+
+// Descriptors returns every registered descriptor in registration order
+func (r *Registry) Descriptors() []Descriptor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Descriptor, len(r.order))
+	for i, id := range r.order {
+		d := r.byID[id]
+		d.Streams = append([]string(nil), d.Streams...)
+		out[i] = d
+	}
+	return out
+}
+
+// Builtins returns the descriptors in the embedded venues.json sorted by ID
+func Builtins() []Descriptor {
+	builtins := builtinDescriptors()
+	out := make([]Descriptor, 0, len(builtins))
+	for _, d := range builtins {
+		d.Streams = append([]string(nil), d.Streams...)
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+var (
+	defaultRegistry     *Registry
+	defaultRegistryErr  error
+	defaultRegistryOnce sync.Once
+)
+
+// Default returns the registry parsed from the embedded venues.json. Venues
+// registered on it are visible to every package-level validator.
+// A parse failure is a build defect and panics.
+func Default() *Registry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistry, defaultRegistryErr = ParseRegistry(embeddedVenues)
+	})
+	if defaultRegistryErr != nil {
+		panic(defaultRegistryErr)
+	}
+	return defaultRegistry
+}
+
+// Register adds a venue to the default registry, where every validator in
+// this module, including sundayschemas.ValidateJSON, accepts it
+func Register(d Descriptor) error {
+	return Default().Register(d)
+}
+
+// Unregister removes a venue from the default registry, e.g. one registered
+// at runtime by a test
+func Unregister(id string) bool {
+	return Default().Unregister(id)
+}
+
+// Lookup returns the descriptor for a venue from the default registry
+func Lookup(id string) (Descriptor, bool) {
+	return Default().Lookup(id)
+}
+
+// IsValid reports whether a venue is in the default registry
+func IsValid(id string) bool {
+	return Default().IsValid(id)
+}
+
+// Validate returns an error if a venue is not in the default registry
+func Validate(id string) error {
+	return Default().Validate(id)
+}
+
+// IDs returns every venue ID in the default registry
+func IDs() []string {
+	return Default().IDs()
+}
@@ -0,0 +1,135 @@
+package venues
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDefault_EmbeddedVenues(t *testing.T) {
+	if got, want := IDs(), []string{"polymarket", "kalshi"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IDs() = %v, want %v", got, want)
+	}
+
+	kalshi, ok := Lookup("kalshi")
+	if !ok || kalshi.PriceUnit != PriceUnitCents || kalshi.DisplayName != "Kalshi" || kalshi.ContractSize != 1 {
+		t.Errorf("Lookup(kalshi) = (%+v, %v)", kalshi, ok)
+	}
+	if p, err := kalshi.ToProbability(65); err != nil || p != 0.65 {
+		t.Errorf("ToProbability(65) = (%v, %v), want 0.65", p, err)
+	}
+
+	polymarket, _ := Lookup("polymarket")
+	if p, err := polymarket.ToProbability(0.65); err != nil || p != 0.65 {
+		t.Errorf("ToProbability(0.65) = (%v, %v), want 0.65", p, err)
+	}
+	if !polymarket.SupportsStream(StreamOrderbook) || polymarket.SupportsStream("event_discovery") {
+		t.Errorf("SupportsStream() wrong for %v", polymarket.Streams)
+	}
+}
+
+func TestRegister_Runtime(t *testing.T) {
+	manifold := Descriptor{ID: "manifold", DisplayName: "Manifold", PriceUnit: PriceUnitProbability, ContractSize: 1, Streams: []string{StreamTrades}}
+	if err := Register(manifold); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { Unregister("manifold") })
+	if !IsValid("manifold") || Validate("manifold") != nil {
+		t.Error("registered venue is not valid")
+	}
+	if err := Register(manifold); err == nil {
+		t.Error("Register() expected error for duplicate venue, got nil")
+	}
+	if err := Register(Descriptor{ID: "nounit", ContractSize: 1}); err == nil {
+		t.Error("Register() expected error for missing price unit, got nil")
+	}
+	if Validate("nounit") == nil {
+		t.Error("Validate() accepted a venue that failed to register")
+	}
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	r, err := ParseRegistry(embeddedVenues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(Descriptor{ID: "manifold", PriceUnit: PriceUnitProbability, ContractSize: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Unregister("polymarket") || r.Unregister("polymarket") {
+		t.Error("Unregister() should report whether the venue was registered")
+	}
+	if got, want := r.IDs(), []string{"kalshi", "manifold"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IDs() = %v, want %v", got, want)
+	}
+	if IDs()[0] != "polymarket" {
+		t.Error("Unregister() on a parsed registry changed the default registry")
+	}
+}
+
+func TestParseRegistry(t *testing.T) {
+	r, err := ParseRegistry([]byte(`["kalshi", {"id": "polymarket", "display_name": "PM"}, {"id": "x", "price_unit": "cents", "contract_size": 10}]`))
+	if err != nil {
+		t.Fatalf("ParseRegistry() error = %v", err)
+	}
+	pm, _ := r.Lookup("polymarket")
+	if pm.DisplayName != "PM" || pm.PriceUnit != PriceUnitProbability || len(pm.Streams) != 3 {
+		t.Errorf("builtin fallback not applied: %+v", pm)
+	}
+	x, _ := r.Lookup("x")
+	if x.DisplayName != "x" || x.ContractSize != 10 {
+		t.Errorf("Lookup(x) = %+v", x)
+	}
+
+	if _, err := ParseRegistry([]byte(`["manifold"]`)); err == nil {
+		t.Error("ParseRegistry() expected error for unknown bare venue, got nil")
+	}
+	if _, err := ParseRegistry([]byte(`["kalshi", "kalshi"]`)); err == nil {
+		t.Error("ParseRegistry() expected error for duplicate venue, got nil")
+	}
+}
+
+func TestBuiltins_FromEmbeddedJSON(t *testing.T) {
+	builtins := Builtins()
+	if len(builtins) != 2 || builtins[0].ID != "kalshi" || builtins[1].ID != "polymarket" {
+		t.Fatalf("Builtins() = %+v", builtins)
+	}
+	for _, d := range builtins {
+		if err := d.Validate(); err != nil || len(d.Streams) == 0 {
+			t.Errorf("embedded descriptor %s is incomplete: %+v, %v", d.ID, d, err)
+		}
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	r := &Registry{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			r.Register(Descriptor{ID: string(rune('a' + i)), PriceUnit: PriceUnitCents, ContractSize: 1})
+		}(i)
+		go func() {
+			defer wg.Done()
+			r.IsValid("a")
+			r.Descriptors()
+		}()
+	}
+	wg.Wait()
+	if len(r.IDs()) != 8 {
+		t.Errorf("IDs() = %v, want 8 venues", r.IDs())
+	}
+}
+
+func TestEmbeddedVenuesInSync(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("..", "..", "..", "schemas", "registries", "venues.json"))
+	if err != nil {
+		t.Fatalf("failed to read venues.json: %v", err)
+	}
+	if string(embeddedVenues) != string(want) {
+		t.Error("venues.json is out of date; run 'npm run generate-go'")
+	}
+}
@@ -175,6 +175,18 @@ export interface FinancialDataV0 {
    * Total USD liquidity, decimal precision to 2 places
    */
   liquidity_total_usd?: number;
+  /**
+   * 24-hour volume in the native currency, decimal precision up to the currency's minor units
+   */
+  volume_24h_native?: number;
+  /**
+   * Total volume in the native currency, decimal precision up to the currency's minor units
+   */
+  volume_total_native?: number;
+  /**
+   * Total liquidity in the native currency, decimal precision up to the currency's minor units
+   */
+  liquidity_total_native?: number;
   /**
    * 24-hour contract volume count
    */
@@ -188,9 +200,9 @@ export interface FinancialDataV0 {
    */
   score?: number;
   /**
-   * Currency unit for monetary values
+   * Currency of the *_native amounts; *_usd amounts are always USD. Defaults to USD
    */
-  currency?: "USD";
+  currency?: "USD" | "USDC" | "EUR" | "GBP";
 }
 export interface StatusDataV0 {
   archived?: boolean;
@@ -143,6 +143,14 @@ function checkTopicsCompatibility(currentTopics, previousTopics) {
   return issues;
 }
 
+// venueIds reduces venues.json entries, descriptors or bare IDs, to their IDs
+function venueIds(venues) {
+  if (!Array.isArray(venues)) {
+    return venues;
+  }
+  return venues.map(venue => (typeof venue === 'string' ? venue : venue.id));
+}
+
 function checkVenuesCompatibility(currentVenues, previousVenues) {
   const issues = [];
 
@@ -279,8 +287,8 @@ async function main() {
     const currentContent = fs.readFileSync(VENUES_FILE, 'utf8');
     const previousContent = getFileAtRef(VENUES_FILE, gitRef);
 
-    const currentVenues = parseJson(currentContent);
-    const previousVenues = parseJson(previousContent);
+    const currentVenues = venueIds(parseJson(currentContent));
+    const previousVenues = venueIds(parseJson(previousContent));
 
     if (currentVenues) {
       const issues = checkVenuesCompatibility(currentVenues, previousVenues);
@@ -17,7 +17,9 @@ function loadVenues() {
   }
 
   try {
-    return JSON.parse(fs.readFileSync(VENUES_REGISTRY, 'utf8'));
+    // Entries are venue descriptors; bare IDs are still accepted
+    return JSON.parse(fs.readFileSync(VENUES_REGISTRY, 'utf8'))
+      .map(venue => (typeof venue === 'string' ? venue : venue.id));
   } catch (error) {
     console.error('❌ Failed to parse venues.json:', error.message);
     return null;
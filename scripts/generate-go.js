@@ -45,6 +45,8 @@ function generateGoTypes() {
     const command = `quicktype --src-lang schema --lang go --package sundayschemas --top-level SundaySchemas ${schemaPathsStr} --out "${outputFile}"`;
     execSync(command, { stdio: 'pipe' });
 
+    usePriceLevels(outputFile);
+
     console.log(`✅ Generated: ${path.relative(process.cwd(), outputFile)}`);
   } catch (error) {
     console.error(`❌ Failed to generate types:`, error.message);
@@ -54,6 +56,9 @@ function generateGoTypes() {
   // Generate a combined constants file
   generateConstantsFile(schemaFiles);
 
+  // Copy schema documents for the embedded Go schema registry
+  copyEmbeddedSchemas();
+
   console.log('\n✨ Go type generation completed successfully');
 }
 
@@ -64,7 +69,9 @@ function generateConstantsFile(schemaFiles) {
   const schemaConstants = schemaFiles.map(f => {
     const filePath = path.join(SCHEMAS_DIR, f);
     const schemaContent = JSON.parse(fs.readFileSync(filePath, 'utf8'));
-    const schemaId = schemaContent.properties?.schema?.const;
+    // Discovery schemas carry their identifier inside the envelope
+    const schemaId = schemaContent.properties?.schema?.const
+      ?? schemaContent.properties?.envelope?.properties?.schema?.const;
     if (!schemaId) {
       console.warn(`Warning: No schema const found in ${f}`);
       return null;
@@ -82,6 +89,8 @@ package sundayschemas
 
 import (
 	"fmt"
+
+	"github.com/rakeyshgidwani/sunday-schemas/codegen/go/venues"
 )
 
 // EventSchema represents all valid schema identifiers
@@ -124,14 +133,9 @@ func ValidateSchema(schema string) error {
 	}
 }
 
-// ValidateVenue checks if a venue ID is valid
+// ValidateVenue checks if a venue ID is in the venue registry
 func ValidateVenue(venue string) error {
-	switch VenueID(venue) {
-	case VenuePolymarket, VenueKalshi:
-		return nil
-	default:
-		return fmt.Errorf("invalid venue: %s", venue)
-	}
+	return venues.Validate(venue)
 }
 
 // AllSchemas returns all valid schema constants
@@ -141,9 +145,14 @@ func AllSchemas() []EventSchema {
 	}
 }
 
-// AllVenues returns all valid venue IDs
+// AllVenues returns all venue IDs in the venue registry
 func AllVenues() []VenueID {
-	return []VenueID{VenuePolymarket, VenueKalshi}
+	ids := venues.IDs()
+	out := make([]VenueID, len(ids))
+	for i, id := range ids {
+		out[i] = VenueID(id)
+	}
+	return out
 }
 `;
 
@@ -152,6 +161,44 @@ func AllVenues() []VenueID {
   console.log(`✅ Generated constants file: ${path.relative(process.cwd(), outputPath)}`);
 }
 
+// quicktype renders [price, size] tuples as [][]float64; the orderbook ladders
+// use the hand-written PriceLevel type (pricelevel.go) instead
+function usePriceLevels(outputFile) {
+  const source = fs.readFileSync(outputFile, 'utf8');
+  const patched = source.replace(/\[\]\[\]float64(\s+`json:"(?:bids|asks)"`)/g, '[]PriceLevel$1');
+  fs.writeFileSync(outputFile, patched);
+}
+
+function copyEmbeddedSchemas() {
+  console.log('\n📦 Copying schemas and registries for Go embedding...');
+
+  const embedDir = path.join(OUTPUT_DIR, 'jsonschema');
+  if (!fs.existsSync(embedDir)) {
+    fs.mkdirSync(embedDir, { recursive: true });
+  }
+
+  // Remove stale copies so deleted schemas don't linger in the module
+  fs.readdirSync(embedDir)
+    .filter(f => f.endsWith('.json'))
+    .forEach(f => fs.unlinkSync(path.join(embedDir, f)));
+
+  const files = fs.readdirSync(SCHEMAS_DIR).filter(f => f.endsWith('.json')).sort();
+  for (const file of files) {
+    fs.copyFileSync(path.join(SCHEMAS_DIR, file), path.join(embedDir, file));
+  }
+
+  console.log(`✅ Copied ${files.length} schemas to ${path.relative(process.cwd(), embedDir)}`);
+
+  // Topic registry is embedded from the module root
+  fs.copyFileSync(path.join(SCHEMAS_DIR, '../topics.json'), path.join(OUTPUT_DIR, 'topics.json'));
+  console.log(`✅ Copied topics.json to ${path.relative(process.cwd(), OUTPUT_DIR)}`);
+
+  // Venue registry is embedded by the venues package
+  const venuesDir = path.join(OUTPUT_DIR, 'venues');
+  fs.copyFileSync(path.join(SCHEMAS_DIR, '../registries/venues.json'), path.join(venuesDir, 'venues.json'));
+  console.log(`✅ Copied venues.json to ${path.relative(process.cwd(), venuesDir)}`);
+}
+
 function pascalCase(str) {
   return str.split(/[\._\-]/)
     .map(part => part.charAt(0).toUpperCase() + part.slice(1).toLowerCase())